@@ -0,0 +1,170 @@
+package goplum_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"chameth.com/goplum"
+	"chameth.com/goplum/plugins/debug"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcknowledge_StopsRemindersUntilRecovery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goplum.conf")
+	writeConfig(t, path, `
+alert debug.sysout "chat" {}
+alert debug.sysout "phone" {}
+
+escalation "critical" {
+  chat { alerts = ["chat"] }
+  phone {
+    after = 30m
+    alerts = ["phone"]
+  }
+}
+
+check debug.random "website" {
+  percent_good = 0.0
+  escalation_policy = "critical"
+  reminder = 1h
+}
+`)
+
+	plum := goplum.NewPlum()
+	plum.RegisterPlugins(plugins)
+	require.NoError(t, plum.ReadConfig(path))
+
+	var alerted []string
+	plum.AddAlertListener(func(name string, _ goplum.AlertDetails, _ error) {
+		alerted = append(alerted, name)
+	})
+
+	check := plum.Checks["website"]
+	_, err := plum.Acknowledge("website", "", "")
+	assert.EqualError(t, err, "check website is not failing or warning")
+
+	check.Settled = true
+	plum.RunCheck(check)
+	plum.RunCheck(check)
+	require.Equal(t, goplum.StateFailing, check.State)
+
+	_, err = plum.Acknowledge("website", "Looking into it", "alice")
+	require.NoError(t, err)
+	assert.True(t, check.Acknowledged)
+	assert.Equal(t, "alice", check.AcknowledgedBy)
+	assert.Equal(t, "Looking into it", check.AcknowledgedMessage)
+
+	// Neither escalations nor reminders are sent while acknowledged.
+	alerted = nil
+	check.FailingSince = time.Now().Add(-time.Hour)
+	check.LastAlertTime = time.Now().Add(-2 * time.Hour)
+	plum.RunCheck(check)
+	assert.Empty(t, alerted)
+
+	// Recovering clears the acknowledgement.
+	check.Check = debug.RandomCheck{PercentGood: 1.0}
+	plum.RunCheck(check)
+	plum.RunCheck(check)
+	assert.Equal(t, goplum.StateGood, check.State)
+	assert.False(t, check.Acknowledged)
+	assert.Empty(t, check.AcknowledgedBy)
+}
+
+// fixedCheck always returns a result with the same state.
+type fixedCheck goplum.CheckState
+
+func (f fixedCheck) Execute(_ context.Context) goplum.Result {
+	return goplum.Result{State: goplum.CheckState(f), Time: time.Now()}
+}
+
+func TestAcknowledge_ClearedWhenWarningBecomesFailing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goplum.conf")
+	writeConfig(t, path, `
+alert debug.sysout "chat" {}
+
+check debug.random "website" {
+  percent_good = 1.0
+  reminder = 1h
+}
+`)
+
+	plum := goplum.NewPlum()
+	plum.RegisterPlugins(plugins)
+	require.NoError(t, plum.ReadConfig(path))
+
+	var alerted []goplum.AlertDetails
+	plum.AddAlertListener(func(_ string, details goplum.AlertDetails, _ error) {
+		alerted = append(alerted, details)
+	})
+
+	check := plum.Checks["website"]
+	check.Settled = true
+	check.Check = fixedCheck(goplum.StateWarning)
+	for range check.Config.WarningThreshold {
+		plum.RunCheck(check)
+	}
+	require.Equal(t, goplum.StateWarning, check.State)
+
+	_, err := plum.Acknowledge("website", "Certificate renewal scheduled", "alice")
+	require.NoError(t, err)
+	require.True(t, check.Acknowledged, "warning checks can be acknowledged")
+
+	alerted = nil
+	check.Check = fixedCheck(goplum.StateFailing)
+	for range check.Config.FailingThreshold {
+		plum.RunCheck(check)
+	}
+	require.Equal(t, goplum.StateFailing, check.State)
+	assert.False(t, check.Acknowledged, "acknowledgement should be cleared when the check gets worse")
+	require.Len(t, alerted, 1)
+	assert.Equal(t, goplum.StateFailing, alerted[0].NewState)
+
+	// Reminders are sent for the failure, as it wasn't acknowledged.
+	alerted = nil
+	check.LastAlertTime = time.Now().Add(-2 * time.Hour)
+	plum.RunCheck(check)
+	require.Len(t, alerted, 1)
+	assert.True(t, alerted[0].IsReminder)
+}
+
+func TestAcknowledge_Concurrently(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goplum.conf")
+	writeConfig(t, path, `
+alert debug.sysout "chat" {}
+
+check debug.random "website" {
+  percent_good = 0.0
+}
+`)
+
+	plum := goplum.NewPlum()
+	plum.RegisterPlugins(plugins)
+	require.NoError(t, plum.ReadConfig(path))
+
+	check := plum.Checks["website"]
+	check.Settled = true
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		for i := range 1000 {
+			// Recover for the last 10 of every 100 runs, so the acknowledgement is repeatedly cleared.
+			check.Check = debug.RandomCheck{PercentGood: float64(i % 100 / 90)}
+			plum.RunCheck(check)
+		}
+	}()
+	for _, by := range []string{"alice", "bob"} {
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				_, _ = plum.Acknowledge("website", "", by)
+			}
+		}()
+	}
+	wg.Wait()
+}
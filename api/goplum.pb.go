@@ -27,6 +27,7 @@ const (
 	Status_INDETERMINATE Status = 0
 	Status_GOOD          Status = 1
 	Status_FAILING       Status = 2
+	Status_WARNING       Status = 3
 )
 
 // Enum value maps for Status.
@@ -35,11 +36,13 @@ var (
 		0: "INDETERMINATE",
 		1: "GOOD",
 		2: "FAILING",
+		3: "WARNING",
 	}
 	Status_value = map[string]int32{
 		"INDETERMINATE": 0,
 		"GOOD":          1,
 		"FAILING":       2,
+		"WARNING":       3,
 	}
 )
 
@@ -159,15 +162,23 @@ func (x *CheckList) GetChecks() []*Check {
 }
 
 type Check struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	LastRun       int64                  `protobuf:"varint,3,opt,name=last_run,json=lastRun,proto3" json:"last_run,omitempty"`
-	Settled       bool                   `protobuf:"varint,4,opt,name=settled,proto3" json:"settled,omitempty"`
-	State         Status                 `protobuf:"varint,5,opt,name=state,proto3,enum=api.Status" json:"state,omitempty"`
-	Suspended     bool                   `protobuf:"varint,6,opt,name=suspended,proto3" json:"suspended,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Name                string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	LastRun             int64                  `protobuf:"varint,3,opt,name=last_run,json=lastRun,proto3" json:"last_run,omitempty"`
+	Settled             bool                   `protobuf:"varint,4,opt,name=settled,proto3" json:"settled,omitempty"`
+	State               Status                 `protobuf:"varint,5,opt,name=state,proto3,enum=api.Status" json:"state,omitempty"`
+	Suspended           bool                   `protobuf:"varint,6,opt,name=suspended,proto3" json:"suspended,omitempty"`
+	Maintenance         string                 `protobuf:"bytes,7,opt,name=maintenance,proto3" json:"maintenance,omitempty"`
+	SuspendedUntil      int64                  `protobuf:"varint,8,opt,name=suspended_until,json=suspendedUntil,proto3" json:"suspended_until,omitempty"`
+	SuspendedReason     string                 `protobuf:"bytes,9,opt,name=suspended_reason,json=suspendedReason,proto3" json:"suspended_reason,omitempty"`
+	SuspendedBy         string                 `protobuf:"bytes,10,opt,name=suspended_by,json=suspendedBy,proto3" json:"suspended_by,omitempty"`
+	Acknowledged        bool                   `protobuf:"varint,11,opt,name=acknowledged,proto3" json:"acknowledged,omitempty"`
+	AcknowledgedAt      int64                  `protobuf:"varint,12,opt,name=acknowledged_at,json=acknowledgedAt,proto3" json:"acknowledged_at,omitempty"`
+	AcknowledgedBy      string                 `protobuf:"bytes,13,opt,name=acknowledged_by,json=acknowledgedBy,proto3" json:"acknowledged_by,omitempty"`
+	AcknowledgedMessage string                 `protobuf:"bytes,14,opt,name=acknowledged_message,json=acknowledgedMessage,proto3" json:"acknowledged_message,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *Check) Reset() {
@@ -242,6 +253,174 @@ func (x *Check) GetSuspended() bool {
 	return false
 }
 
+func (x *Check) GetMaintenance() string {
+	if x != nil {
+		return x.Maintenance
+	}
+	return ""
+}
+
+func (x *Check) GetSuspendedUntil() int64 {
+	if x != nil {
+		return x.SuspendedUntil
+	}
+	return 0
+}
+
+func (x *Check) GetSuspendedReason() string {
+	if x != nil {
+		return x.SuspendedReason
+	}
+	return ""
+}
+
+func (x *Check) GetSuspendedBy() string {
+	if x != nil {
+		return x.SuspendedBy
+	}
+	return ""
+}
+
+func (x *Check) GetAcknowledged() bool {
+	if x != nil {
+		return x.Acknowledged
+	}
+	return false
+}
+
+func (x *Check) GetAcknowledgedAt() int64 {
+	if x != nil {
+		return x.AcknowledgedAt
+	}
+	return 0
+}
+
+func (x *Check) GetAcknowledgedBy() string {
+	if x != nil {
+		return x.AcknowledgedBy
+	}
+	return ""
+}
+
+func (x *Check) GetAcknowledgedMessage() string {
+	if x != nil {
+		return x.AcknowledgedMessage
+	}
+	return ""
+}
+
+type AcknowledgeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcknowledgeRequest) Reset() {
+	*x = AcknowledgeRequest{}
+	mi := &file_goplum_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcknowledgeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcknowledgeRequest) ProtoMessage() {}
+
+func (x *AcknowledgeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goplum_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcknowledgeRequest.ProtoReflect.Descriptor instead.
+func (*AcknowledgeRequest) Descriptor() ([]byte, []int) {
+	return file_goplum_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AcknowledgeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AcknowledgeRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type SuspendRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Duration      int64                  `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuspendRequest) Reset() {
+	*x = SuspendRequest{}
+	mi := &file_goplum_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuspendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspendRequest) ProtoMessage() {}
+
+func (x *SuspendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goplum_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspendRequest.ProtoReflect.Descriptor instead.
+func (*SuspendRequest) Descriptor() ([]byte, []int) {
+	return file_goplum_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SuspendRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SuspendRequest) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *SuspendRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type Fact struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -249,6 +428,7 @@ type Fact struct {
 	//
 	//	*Fact_Int
 	//	*Fact_Str
+	//	*Fact_Double
 	Value         isFact_Value `protobuf_oneof:"value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -256,7 +436,7 @@ type Fact struct {
 
 func (x *Fact) Reset() {
 	*x = Fact{}
-	mi := &file_goplum_proto_msgTypes[3]
+	mi := &file_goplum_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -268,7 +448,7 @@ func (x *Fact) String() string {
 func (*Fact) ProtoMessage() {}
 
 func (x *Fact) ProtoReflect() protoreflect.Message {
-	mi := &file_goplum_proto_msgTypes[3]
+	mi := &file_goplum_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -281,7 +461,7 @@ func (x *Fact) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Fact.ProtoReflect.Descriptor instead.
 func (*Fact) Descriptor() ([]byte, []int) {
-	return file_goplum_proto_rawDescGZIP(), []int{3}
+	return file_goplum_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Fact) GetName() string {
@@ -316,6 +496,15 @@ func (x *Fact) GetStr() string {
 	return ""
 }
 
+func (x *Fact) GetDouble() float64 {
+	if x != nil {
+		if x, ok := x.Value.(*Fact_Double); ok {
+			return x.Double
+		}
+	}
+	return 0
+}
+
 type isFact_Value interface {
 	isFact_Value()
 }
@@ -328,10 +517,16 @@ type Fact_Str struct {
 	Str string `protobuf:"bytes,3,opt,name=str,proto3,oneof"`
 }
 
+type Fact_Double struct {
+	Double float64 `protobuf:"fixed64,4,opt,name=double,proto3,oneof"`
+}
+
 func (*Fact_Int) isFact_Value() {}
 
 func (*Fact_Str) isFact_Value() {}
 
+func (*Fact_Double) isFact_Value() {}
+
 type Result struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Check         string                 `protobuf:"bytes,1,opt,name=check,proto3" json:"check,omitempty"`
@@ -345,7 +540,7 @@ type Result struct {
 
 func (x *Result) Reset() {
 	*x = Result{}
-	mi := &file_goplum_proto_msgTypes[4]
+	mi := &file_goplum_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -357,7 +552,7 @@ func (x *Result) String() string {
 func (*Result) ProtoMessage() {}
 
 func (x *Result) ProtoReflect() protoreflect.Message {
-	mi := &file_goplum_proto_msgTypes[4]
+	mi := &file_goplum_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -370,7 +565,7 @@ func (x *Result) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Result.ProtoReflect.Descriptor instead.
 func (*Result) Descriptor() ([]byte, []int) {
-	return file_goplum_proto_rawDescGZIP(), []int{4}
+	return file_goplum_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Result) GetCheck() string {
@@ -408,6 +603,290 @@ func (x *Result) GetFacts() []*Fact {
 	return nil
 }
 
+type ResultList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*Result              `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResultList) Reset() {
+	*x = ResultList{}
+	mi := &file_goplum_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResultList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResultList) ProtoMessage() {}
+
+func (x *ResultList) ProtoReflect() protoreflect.Message {
+	mi := &file_goplum_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResultList.ProtoReflect.Descriptor instead.
+func (*ResultList) Descriptor() ([]byte, []int) {
+	return file_goplum_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ResultList) GetResults() []*Result {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type HistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Check         string                 `protobuf:"bytes,1,opt,name=check,proto3" json:"check,omitempty"`
+	From          int64                  `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            int64                  `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryRequest) Reset() {
+	*x = HistoryRequest{}
+	mi := &file_goplum_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryRequest) ProtoMessage() {}
+
+func (x *HistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goplum_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryRequest.ProtoReflect.Descriptor instead.
+func (*HistoryRequest) Descriptor() ([]byte, []int) {
+	return file_goplum_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *HistoryRequest) GetCheck() string {
+	if x != nil {
+		return x.Check
+	}
+	return ""
+}
+
+func (x *HistoryRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *HistoryRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type MaintenanceWindow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Checks        []string               `protobuf:"bytes,2,rep,name=checks,proto3" json:"checks,omitempty"`
+	Schedule      string                 `protobuf:"bytes,3,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	Start         int64                  `protobuf:"varint,4,opt,name=start,proto3" json:"start,omitempty"`
+	End           int64                  `protobuf:"varint,5,opt,name=end,proto3" json:"end,omitempty"`
+	Active        bool                   `protobuf:"varint,6,opt,name=active,proto3" json:"active,omitempty"`
+	AdHoc         bool                   `protobuf:"varint,7,opt,name=ad_hoc,json=adHoc,proto3" json:"ad_hoc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MaintenanceWindow) Reset() {
+	*x = MaintenanceWindow{}
+	mi := &file_goplum_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaintenanceWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaintenanceWindow) ProtoMessage() {}
+
+func (x *MaintenanceWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_goplum_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaintenanceWindow.ProtoReflect.Descriptor instead.
+func (*MaintenanceWindow) Descriptor() ([]byte, []int) {
+	return file_goplum_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MaintenanceWindow) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetChecks() []string {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+func (x *MaintenanceWindow) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *MaintenanceWindow) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *MaintenanceWindow) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *MaintenanceWindow) GetAdHoc() bool {
+	if x != nil {
+		return x.AdHoc
+	}
+	return false
+}
+
+type MaintenanceWindowName struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MaintenanceWindowName) Reset() {
+	*x = MaintenanceWindowName{}
+	mi := &file_goplum_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaintenanceWindowName) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaintenanceWindowName) ProtoMessage() {}
+
+func (x *MaintenanceWindowName) ProtoReflect() protoreflect.Message {
+	mi := &file_goplum_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaintenanceWindowName.ProtoReflect.Descriptor instead.
+func (*MaintenanceWindowName) Descriptor() ([]byte, []int) {
+	return file_goplum_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MaintenanceWindowName) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type MaintenanceWindowList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Windows       []*MaintenanceWindow   `protobuf:"bytes,1,rep,name=windows,proto3" json:"windows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MaintenanceWindowList) Reset() {
+	*x = MaintenanceWindowList{}
+	mi := &file_goplum_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaintenanceWindowList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaintenanceWindowList) ProtoMessage() {}
+
+func (x *MaintenanceWindowList) ProtoReflect() protoreflect.Message {
+	mi := &file_goplum_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaintenanceWindowList.ProtoReflect.Descriptor instead.
+func (*MaintenanceWindowList) Descriptor() ([]byte, []int) {
+	return file_goplum_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MaintenanceWindowList) GetWindows() []*MaintenanceWindow {
+	if x != nil {
+		return x.Windows
+	}
+	return nil
+}
+
 type Empty struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -416,7 +895,7 @@ type Empty struct {
 
 func (x *Empty) Reset() {
 	*x = Empty{}
-	mi := &file_goplum_proto_msgTypes[5]
+	mi := &file_goplum_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -428,7 +907,7 @@ func (x *Empty) String() string {
 func (*Empty) ProtoMessage() {}
 
 func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_goplum_proto_msgTypes[5]
+	mi := &file_goplum_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -441,7 +920,7 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Empty.ProtoReflect.Descriptor instead.
 func (*Empty) Descriptor() ([]byte, []int) {
-	return file_goplum_proto_rawDescGZIP(), []int{5}
+	return file_goplum_proto_rawDescGZIP(), []int{12}
 }
 
 var File_goplum_proto protoreflect.FileDescriptor
@@ -453,41 +932,90 @@ const file_goplum_proto_rawDesc = "" +
 	"\x04name\x18\x01 \x01(\tR\x04name\"/\n" +
 	"\tCheckList\x12\"\n" +
 	"\x06checks\x18\x01 \x03(\v2\n" +
-	".api.CheckR\x06checks\"\xa5\x01\n" +
+	".api.CheckR\x06checks\"\xe7\x03\n" +
 	"\x05Check\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\x12\x19\n" +
 	"\blast_run\x18\x03 \x01(\x03R\alastRun\x12\x18\n" +
 	"\asettled\x18\x04 \x01(\bR\asettled\x12!\n" +
 	"\x05state\x18\x05 \x01(\x0e2\v.api.StatusR\x05state\x12\x1c\n" +
-	"\tsuspended\x18\x06 \x01(\bR\tsuspended\"K\n" +
+	"\tsuspended\x18\x06 \x01(\bR\tsuspended\x12 \n" +
+	"\vmaintenance\x18\a \x01(\tR\vmaintenance\x12'\n" +
+	"\x0fsuspended_until\x18\b \x01(\x03R\x0esuspendedUntil\x12)\n" +
+	"\x10suspended_reason\x18\t \x01(\tR\x0fsuspendedReason\x12!\n" +
+	"\fsuspended_by\x18\n" +
+	" \x01(\tR\vsuspendedBy\x12\"\n" +
+	"\facknowledged\x18\v \x01(\bR\facknowledged\x12'\n" +
+	"\x0facknowledged_at\x18\f \x01(\x03R\x0eacknowledgedAt\x12'\n" +
+	"\x0facknowledged_by\x18\r \x01(\tR\x0eacknowledgedBy\x121\n" +
+	"\x14acknowledged_message\x18\x0e \x01(\tR\x13acknowledgedMessage\"B\n" +
+	"\x12AcknowledgeRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"X\n" +
+	"\x0eSuspendRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bduration\x18\x02 \x01(\x03R\bduration\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"e\n" +
 	"\x04Fact\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
 	"\x03int\x18\x02 \x01(\x03H\x00R\x03int\x12\x12\n" +
-	"\x03str\x18\x03 \x01(\tH\x00R\x03strB\a\n" +
+	"\x03str\x18\x03 \x01(\tH\x00R\x03str\x12\x18\n" +
+	"\x06double\x18\x04 \x01(\x01H\x00R\x06doubleB\a\n" +
 	"\x05value\"\x90\x01\n" +
 	"\x06Result\x12\x14\n" +
 	"\x05check\x18\x01 \x01(\tR\x05check\x12\x12\n" +
 	"\x04time\x18\x02 \x01(\x03R\x04time\x12#\n" +
 	"\x06result\x18\x03 \x01(\x0e2\v.api.StatusR\x06result\x12\x16\n" +
 	"\x06detail\x18\x04 \x01(\tR\x06detail\x12\x1f\n" +
-	"\x05facts\x18\x05 \x03(\v2\t.api.FactR\x05facts\"\a\n" +
-	"\x05Empty*2\n" +
+	"\x05facts\x18\x05 \x03(\v2\t.api.FactR\x05facts\"3\n" +
+	"\n" +
+	"ResultList\x12%\n" +
+	"\aresults\x18\x01 \x03(\v2\v.api.ResultR\aresults\"J\n" +
+	"\x0eHistoryRequest\x12\x14\n" +
+	"\x05check\x18\x01 \x01(\tR\x05check\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x03R\x02to\"\xb2\x01\n" +
+	"\x11MaintenanceWindow\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06checks\x18\x02 \x03(\tR\x06checks\x12\x1a\n" +
+	"\bschedule\x18\x03 \x01(\tR\bschedule\x12\x14\n" +
+	"\x05start\x18\x04 \x01(\x03R\x05start\x12\x10\n" +
+	"\x03end\x18\x05 \x01(\x03R\x03end\x12\x16\n" +
+	"\x06active\x18\x06 \x01(\bR\x06active\x12\x15\n" +
+	"\x06ad_hoc\x18\a \x01(\bR\x05adHoc\"+\n" +
+	"\x15MaintenanceWindowName\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"I\n" +
+	"\x15MaintenanceWindowList\x120\n" +
+	"\awindows\x18\x01 \x03(\v2\x16.api.MaintenanceWindowR\awindows\"\a\n" +
+	"\x05Empty*?\n" +
 	"\x06Status\x12\x11\n" +
 	"\rINDETERMINATE\x10\x00\x12\b\n" +
 	"\x04GOOD\x10\x01\x12\v\n" +
-	"\aFAILING\x10\x022\xd6\x01\n" +
+	"\aFAILING\x10\x02\x12\v\n" +
+	"\aWARNING\x10\x032\xbc\x04\n" +
 	"\x06GoPlum\x12$\n" +
 	"\aResults\x12\n" +
 	".api.Empty\x1a\v.api.Result0\x01\x12'\n" +
 	"\tGetChecks\x12\n" +
 	".api.Empty\x1a\x0e.api.CheckList\x12&\n" +
 	"\bGetCheck\x12\x0e.api.CheckName\x1a\n" +
-	".api.Check\x12*\n" +
-	"\fSuspendCheck\x12\x0e.api.CheckName\x1a\n" +
+	".api.Check\x12/\n" +
+	"\fSuspendCheck\x12\x13.api.SuspendRequest\x1a\n" +
 	".api.Check\x12)\n" +
 	"\vResumeCheck\x12\x0e.api.CheckName\x1a\n" +
-	".api.CheckB\x18Z\x16chameth.com/goplum/apib\x06proto3"
+	".api.Check\x127\n" +
+	"\x10AcknowledgeCheck\x12\x17.api.AcknowledgeRequest\x1a\n" +
+	".api.Check\x12&\n" +
+	"\fReloadConfig\x12\n" +
+	".api.Empty\x1a\n" +
+	".api.Empty\x122\n" +
+	"\n" +
+	"GetHistory\x12\x13.api.HistoryRequest\x1a\x0f.api.ResultList\x12?\n" +
+	"\x15GetMaintenanceWindows\x12\n" +
+	".api.Empty\x1a\x1a.api.MaintenanceWindowList\x12F\n" +
+	"\x14AddMaintenanceWindow\x12\x16.api.MaintenanceWindow\x1a\x16.api.MaintenanceWindow\x12A\n" +
+	"\x17RemoveMaintenanceWindow\x12\x1a.api.MaintenanceWindowName\x1a\n" +
+	".api.EmptyB\x18Z\x16chameth.com/goplum/apib\x06proto3"
 
 var (
 	file_goplum_proto_rawDescOnce sync.Once
@@ -502,36 +1030,57 @@ func file_goplum_proto_rawDescGZIP() []byte {
 }
 
 var file_goplum_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_goplum_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_goplum_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_goplum_proto_goTypes = []any{
-	(Status)(0),       // 0: api.Status
-	(*CheckName)(nil), // 1: api.CheckName
-	(*CheckList)(nil), // 2: api.CheckList
-	(*Check)(nil),     // 3: api.Check
-	(*Fact)(nil),      // 4: api.Fact
-	(*Result)(nil),    // 5: api.Result
-	(*Empty)(nil),     // 6: api.Empty
+	(Status)(0),                   // 0: api.Status
+	(*CheckName)(nil),             // 1: api.CheckName
+	(*CheckList)(nil),             // 2: api.CheckList
+	(*Check)(nil),                 // 3: api.Check
+	(*AcknowledgeRequest)(nil),    // 4: api.AcknowledgeRequest
+	(*SuspendRequest)(nil),        // 5: api.SuspendRequest
+	(*Fact)(nil),                  // 6: api.Fact
+	(*Result)(nil),                // 7: api.Result
+	(*ResultList)(nil),            // 8: api.ResultList
+	(*HistoryRequest)(nil),        // 9: api.HistoryRequest
+	(*MaintenanceWindow)(nil),     // 10: api.MaintenanceWindow
+	(*MaintenanceWindowName)(nil), // 11: api.MaintenanceWindowName
+	(*MaintenanceWindowList)(nil), // 12: api.MaintenanceWindowList
+	(*Empty)(nil),                 // 13: api.Empty
 }
 var file_goplum_proto_depIdxs = []int32{
-	3, // 0: api.CheckList.checks:type_name -> api.Check
-	0, // 1: api.Check.state:type_name -> api.Status
-	0, // 2: api.Result.result:type_name -> api.Status
-	4, // 3: api.Result.facts:type_name -> api.Fact
-	6, // 4: api.GoPlum.Results:input_type -> api.Empty
-	6, // 5: api.GoPlum.GetChecks:input_type -> api.Empty
-	1, // 6: api.GoPlum.GetCheck:input_type -> api.CheckName
-	1, // 7: api.GoPlum.SuspendCheck:input_type -> api.CheckName
-	1, // 8: api.GoPlum.ResumeCheck:input_type -> api.CheckName
-	5, // 9: api.GoPlum.Results:output_type -> api.Result
-	2, // 10: api.GoPlum.GetChecks:output_type -> api.CheckList
-	3, // 11: api.GoPlum.GetCheck:output_type -> api.Check
-	3, // 12: api.GoPlum.SuspendCheck:output_type -> api.Check
-	3, // 13: api.GoPlum.ResumeCheck:output_type -> api.Check
-	9, // [9:14] is the sub-list for method output_type
-	4, // [4:9] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	3,  // 0: api.CheckList.checks:type_name -> api.Check
+	0,  // 1: api.Check.state:type_name -> api.Status
+	0,  // 2: api.Result.result:type_name -> api.Status
+	6,  // 3: api.Result.facts:type_name -> api.Fact
+	7,  // 4: api.ResultList.results:type_name -> api.Result
+	10, // 5: api.MaintenanceWindowList.windows:type_name -> api.MaintenanceWindow
+	13, // 6: api.GoPlum.Results:input_type -> api.Empty
+	13, // 7: api.GoPlum.GetChecks:input_type -> api.Empty
+	1,  // 8: api.GoPlum.GetCheck:input_type -> api.CheckName
+	5,  // 9: api.GoPlum.SuspendCheck:input_type -> api.SuspendRequest
+	1,  // 10: api.GoPlum.ResumeCheck:input_type -> api.CheckName
+	4,  // 11: api.GoPlum.AcknowledgeCheck:input_type -> api.AcknowledgeRequest
+	13, // 12: api.GoPlum.ReloadConfig:input_type -> api.Empty
+	9,  // 13: api.GoPlum.GetHistory:input_type -> api.HistoryRequest
+	13, // 14: api.GoPlum.GetMaintenanceWindows:input_type -> api.Empty
+	10, // 15: api.GoPlum.AddMaintenanceWindow:input_type -> api.MaintenanceWindow
+	11, // 16: api.GoPlum.RemoveMaintenanceWindow:input_type -> api.MaintenanceWindowName
+	7,  // 17: api.GoPlum.Results:output_type -> api.Result
+	2,  // 18: api.GoPlum.GetChecks:output_type -> api.CheckList
+	3,  // 19: api.GoPlum.GetCheck:output_type -> api.Check
+	3,  // 20: api.GoPlum.SuspendCheck:output_type -> api.Check
+	3,  // 21: api.GoPlum.ResumeCheck:output_type -> api.Check
+	3,  // 22: api.GoPlum.AcknowledgeCheck:output_type -> api.Check
+	13, // 23: api.GoPlum.ReloadConfig:output_type -> api.Empty
+	8,  // 24: api.GoPlum.GetHistory:output_type -> api.ResultList
+	12, // 25: api.GoPlum.GetMaintenanceWindows:output_type -> api.MaintenanceWindowList
+	10, // 26: api.GoPlum.AddMaintenanceWindow:output_type -> api.MaintenanceWindow
+	13, // 27: api.GoPlum.RemoveMaintenanceWindow:output_type -> api.Empty
+	17, // [17:28] is the sub-list for method output_type
+	6,  // [6:17] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_goplum_proto_init() }
@@ -539,9 +1088,10 @@ func file_goplum_proto_init() {
 	if File_goplum_proto != nil {
 		return
 	}
-	file_goplum_proto_msgTypes[3].OneofWrappers = []any{
+	file_goplum_proto_msgTypes[5].OneofWrappers = []any{
 		(*Fact_Int)(nil),
 		(*Fact_Str)(nil),
+		(*Fact_Double)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -549,7 +1099,7 @@ func file_goplum_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_goplum_proto_rawDesc), len(file_goplum_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   6,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
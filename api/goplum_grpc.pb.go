@@ -19,11 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	GoPlum_Results_FullMethodName      = "/api.GoPlum/Results"
-	GoPlum_GetChecks_FullMethodName    = "/api.GoPlum/GetChecks"
-	GoPlum_GetCheck_FullMethodName     = "/api.GoPlum/GetCheck"
-	GoPlum_SuspendCheck_FullMethodName = "/api.GoPlum/SuspendCheck"
-	GoPlum_ResumeCheck_FullMethodName  = "/api.GoPlum/ResumeCheck"
+	GoPlum_Results_FullMethodName                 = "/api.GoPlum/Results"
+	GoPlum_GetChecks_FullMethodName               = "/api.GoPlum/GetChecks"
+	GoPlum_GetCheck_FullMethodName                = "/api.GoPlum/GetCheck"
+	GoPlum_SuspendCheck_FullMethodName            = "/api.GoPlum/SuspendCheck"
+	GoPlum_ResumeCheck_FullMethodName             = "/api.GoPlum/ResumeCheck"
+	GoPlum_AcknowledgeCheck_FullMethodName        = "/api.GoPlum/AcknowledgeCheck"
+	GoPlum_ReloadConfig_FullMethodName            = "/api.GoPlum/ReloadConfig"
+	GoPlum_GetHistory_FullMethodName              = "/api.GoPlum/GetHistory"
+	GoPlum_GetMaintenanceWindows_FullMethodName   = "/api.GoPlum/GetMaintenanceWindows"
+	GoPlum_AddMaintenanceWindow_FullMethodName    = "/api.GoPlum/AddMaintenanceWindow"
+	GoPlum_RemoveMaintenanceWindow_FullMethodName = "/api.GoPlum/RemoveMaintenanceWindow"
 )
 
 // GoPlumClient is the client API for GoPlum service.
@@ -33,8 +39,14 @@ type GoPlumClient interface {
 	Results(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Result], error)
 	GetChecks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CheckList, error)
 	GetCheck(ctx context.Context, in *CheckName, opts ...grpc.CallOption) (*Check, error)
-	SuspendCheck(ctx context.Context, in *CheckName, opts ...grpc.CallOption) (*Check, error)
+	SuspendCheck(ctx context.Context, in *SuspendRequest, opts ...grpc.CallOption) (*Check, error)
 	ResumeCheck(ctx context.Context, in *CheckName, opts ...grpc.CallOption) (*Check, error)
+	AcknowledgeCheck(ctx context.Context, in *AcknowledgeRequest, opts ...grpc.CallOption) (*Check, error)
+	ReloadConfig(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	GetHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*ResultList, error)
+	GetMaintenanceWindows(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MaintenanceWindowList, error)
+	AddMaintenanceWindow(ctx context.Context, in *MaintenanceWindow, opts ...grpc.CallOption) (*MaintenanceWindow, error)
+	RemoveMaintenanceWindow(ctx context.Context, in *MaintenanceWindowName, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type goPlumClient struct {
@@ -84,7 +96,7 @@ func (c *goPlumClient) GetCheck(ctx context.Context, in *CheckName, opts ...grpc
 	return out, nil
 }
 
-func (c *goPlumClient) SuspendCheck(ctx context.Context, in *CheckName, opts ...grpc.CallOption) (*Check, error) {
+func (c *goPlumClient) SuspendCheck(ctx context.Context, in *SuspendRequest, opts ...grpc.CallOption) (*Check, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Check)
 	err := c.cc.Invoke(ctx, GoPlum_SuspendCheck_FullMethodName, in, out, cOpts...)
@@ -104,6 +116,66 @@ func (c *goPlumClient) ResumeCheck(ctx context.Context, in *CheckName, opts ...g
 	return out, nil
 }
 
+func (c *goPlumClient) AcknowledgeCheck(ctx context.Context, in *AcknowledgeRequest, opts ...grpc.CallOption) (*Check, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Check)
+	err := c.cc.Invoke(ctx, GoPlum_AcknowledgeCheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goPlumClient) ReloadConfig(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, GoPlum_ReloadConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goPlumClient) GetHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*ResultList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResultList)
+	err := c.cc.Invoke(ctx, GoPlum_GetHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goPlumClient) GetMaintenanceWindows(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MaintenanceWindowList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MaintenanceWindowList)
+	err := c.cc.Invoke(ctx, GoPlum_GetMaintenanceWindows_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goPlumClient) AddMaintenanceWindow(ctx context.Context, in *MaintenanceWindow, opts ...grpc.CallOption) (*MaintenanceWindow, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MaintenanceWindow)
+	err := c.cc.Invoke(ctx, GoPlum_AddMaintenanceWindow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goPlumClient) RemoveMaintenanceWindow(ctx context.Context, in *MaintenanceWindowName, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, GoPlum_RemoveMaintenanceWindow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GoPlumServer is the server API for GoPlum service.
 // All implementations must embed UnimplementedGoPlumServer
 // for forward compatibility.
@@ -111,8 +183,14 @@ type GoPlumServer interface {
 	Results(*Empty, grpc.ServerStreamingServer[Result]) error
 	GetChecks(context.Context, *Empty) (*CheckList, error)
 	GetCheck(context.Context, *CheckName) (*Check, error)
-	SuspendCheck(context.Context, *CheckName) (*Check, error)
+	SuspendCheck(context.Context, *SuspendRequest) (*Check, error)
 	ResumeCheck(context.Context, *CheckName) (*Check, error)
+	AcknowledgeCheck(context.Context, *AcknowledgeRequest) (*Check, error)
+	ReloadConfig(context.Context, *Empty) (*Empty, error)
+	GetHistory(context.Context, *HistoryRequest) (*ResultList, error)
+	GetMaintenanceWindows(context.Context, *Empty) (*MaintenanceWindowList, error)
+	AddMaintenanceWindow(context.Context, *MaintenanceWindow) (*MaintenanceWindow, error)
+	RemoveMaintenanceWindow(context.Context, *MaintenanceWindowName) (*Empty, error)
 	mustEmbedUnimplementedGoPlumServer()
 }
 
@@ -132,12 +210,30 @@ func (UnimplementedGoPlumServer) GetChecks(context.Context, *Empty) (*CheckList,
 func (UnimplementedGoPlumServer) GetCheck(context.Context, *CheckName) (*Check, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetCheck not implemented")
 }
-func (UnimplementedGoPlumServer) SuspendCheck(context.Context, *CheckName) (*Check, error) {
+func (UnimplementedGoPlumServer) SuspendCheck(context.Context, *SuspendRequest) (*Check, error) {
 	return nil, status.Error(codes.Unimplemented, "method SuspendCheck not implemented")
 }
 func (UnimplementedGoPlumServer) ResumeCheck(context.Context, *CheckName) (*Check, error) {
 	return nil, status.Error(codes.Unimplemented, "method ResumeCheck not implemented")
 }
+func (UnimplementedGoPlumServer) AcknowledgeCheck(context.Context, *AcknowledgeRequest) (*Check, error) {
+	return nil, status.Error(codes.Unimplemented, "method AcknowledgeCheck not implemented")
+}
+func (UnimplementedGoPlumServer) ReloadConfig(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReloadConfig not implemented")
+}
+func (UnimplementedGoPlumServer) GetHistory(context.Context, *HistoryRequest) (*ResultList, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetHistory not implemented")
+}
+func (UnimplementedGoPlumServer) GetMaintenanceWindows(context.Context, *Empty) (*MaintenanceWindowList, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMaintenanceWindows not implemented")
+}
+func (UnimplementedGoPlumServer) AddMaintenanceWindow(context.Context, *MaintenanceWindow) (*MaintenanceWindow, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddMaintenanceWindow not implemented")
+}
+func (UnimplementedGoPlumServer) RemoveMaintenanceWindow(context.Context, *MaintenanceWindowName) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveMaintenanceWindow not implemented")
+}
 func (UnimplementedGoPlumServer) mustEmbedUnimplementedGoPlumServer() {}
 func (UnimplementedGoPlumServer) testEmbeddedByValue()                {}
 
@@ -207,7 +303,7 @@ func _GoPlum_GetCheck_Handler(srv interface{}, ctx context.Context, dec func(int
 }
 
 func _GoPlum_SuspendCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CheckName)
+	in := new(SuspendRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -219,7 +315,7 @@ func _GoPlum_SuspendCheck_Handler(srv interface{}, ctx context.Context, dec func
 		FullMethod: GoPlum_SuspendCheck_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GoPlumServer).SuspendCheck(ctx, req.(*CheckName))
+		return srv.(GoPlumServer).SuspendCheck(ctx, req.(*SuspendRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -242,6 +338,114 @@ func _GoPlum_ResumeCheck_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GoPlum_AcknowledgeCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcknowledgeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoPlumServer).AcknowledgeCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoPlum_AcknowledgeCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoPlumServer).AcknowledgeCheck(ctx, req.(*AcknowledgeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoPlum_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoPlumServer).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoPlum_ReloadConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoPlumServer).ReloadConfig(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoPlum_GetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoPlumServer).GetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoPlum_GetHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoPlumServer).GetHistory(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoPlum_GetMaintenanceWindows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoPlumServer).GetMaintenanceWindows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoPlum_GetMaintenanceWindows_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoPlumServer).GetMaintenanceWindows(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoPlum_AddMaintenanceWindow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MaintenanceWindow)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoPlumServer).AddMaintenanceWindow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoPlum_AddMaintenanceWindow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoPlumServer).AddMaintenanceWindow(ctx, req.(*MaintenanceWindow))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoPlum_RemoveMaintenanceWindow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MaintenanceWindowName)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoPlumServer).RemoveMaintenanceWindow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoPlum_RemoveMaintenanceWindow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoPlumServer).RemoveMaintenanceWindow(ctx, req.(*MaintenanceWindowName))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GoPlum_ServiceDesc is the grpc.ServiceDesc for GoPlum service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -265,6 +469,30 @@ var GoPlum_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ResumeCheck",
 			Handler:    _GoPlum_ResumeCheck_Handler,
 		},
+		{
+			MethodName: "AcknowledgeCheck",
+			Handler:    _GoPlum_AcknowledgeCheck_Handler,
+		},
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _GoPlum_ReloadConfig_Handler,
+		},
+		{
+			MethodName: "GetHistory",
+			Handler:    _GoPlum_GetHistory_Handler,
+		},
+		{
+			MethodName: "GetMaintenanceWindows",
+			Handler:    _GoPlum_GetMaintenanceWindows_Handler,
+		},
+		{
+			MethodName: "AddMaintenanceWindow",
+			Handler:    _GoPlum_AddMaintenanceWindow_Handler,
+		},
+		{
+			MethodName: "RemoveMaintenanceWindow",
+			Handler:    _GoPlum_RemoveMaintenanceWindow_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,14 @@
+//go:build !nodns
+
+package main
+
+import (
+	"chameth.com/goplum"
+	"chameth.com/goplum/plugins/dns"
+)
+
+func init() {
+	plugins["dns"] = func() (goplum.Plugin, error) {
+		return dns.Plugin{}, nil
+	}
+}
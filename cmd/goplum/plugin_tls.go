@@ -0,0 +1,14 @@
+//go:build !notls
+
+package main
+
+import (
+	"chameth.com/goplum"
+	"chameth.com/goplum/plugins/tls"
+)
+
+func init() {
+	plugins["tls"] = func() (goplum.Plugin, error) {
+		return tls.Plugin{}, nil
+	}
+}
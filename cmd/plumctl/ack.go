@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"chameth.com/goplum/api"
+	"github.com/spf13/cobra"
+)
+
+var ackMessage string
+
+var ackCommand = &cobra.Command{
+	Use:     "ack <name>",
+	Short:   "Acknowledge a failing check",
+	Args:    cobra.ExactArgs(1),
+	PreRunE: ConnectToApi,
+	Run: func(cmd *cobra.Command, args []string) {
+		check, err := client.AcknowledgeCheck(context.Background(), &api.AcknowledgeRequest{
+			Name:    args[0],
+			Message: ackMessage,
+		})
+		if err != nil {
+			fmt.Printf("Unable to acknowledge check: %v\n", err)
+			return
+		}
+		fmt.Printf("Acknowledged check %s.\n", check.Name)
+	},
+}
+
+func init() {
+	ackCommand.Flags().StringVar(&ackMessage, "message", "", "Message to record with the acknowledgement")
+	rootCommand.AddCommand(ackCommand)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"chameth.com/goplum/api"
 	"github.com/spf13/cobra"
@@ -28,6 +29,34 @@ var checksCommand = &cobra.Command{
 
 			if c.Suspended {
 				extras = append(extras, "*SUSPENDED*")
+
+				if c.SuspendedBy != "" {
+					extras = append(extras, fmt.Sprintf("[by: %s]", c.SuspendedBy))
+				}
+
+				if c.SuspendedUntil != 0 {
+					extras = append(extras, fmt.Sprintf("[until: %s]", time.Unix(c.SuspendedUntil, 0).Format(time.DateTime)))
+				}
+
+				if c.SuspendedReason != "" {
+					extras = append(extras, fmt.Sprintf("[reason: %s]", c.SuspendedReason))
+				}
+			}
+
+			if c.Maintenance != "" {
+				extras = append(extras, fmt.Sprintf("*MAINTENANCE: %s*", c.Maintenance))
+			}
+
+			if c.Acknowledged {
+				if c.AcknowledgedBy != "" {
+					extras = append(extras, fmt.Sprintf("[acknowledged by: %s]", c.AcknowledgedBy))
+				} else {
+					extras = append(extras, "[acknowledged]")
+				}
+
+				if c.AcknowledgedMessage != "" {
+					extras = append(extras, fmt.Sprintf("[message: %s]", c.AcknowledgedMessage))
+				}
 			}
 
 			if !c.Settled {
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"chameth.com/goplum/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyFrom string
+	historyTo   string
+)
+
+var historyCommand = &cobra.Command{
+	Use:     "history <name>",
+	Short:   "Shows previous results of a check",
+	Args:    cobra.ExactArgs(1),
+	PreRunE: ConnectToApi,
+	Run: func(cmd *cobra.Command, args []string) {
+		from, err := parseTime(historyFrom)
+		if err != nil {
+			fmt.Printf("Invalid start time: %v\n", err)
+			return
+		}
+
+		to, err := parseTime(historyTo)
+		if err != nil {
+			fmt.Printf("Invalid end time: %v\n", err)
+			return
+		}
+
+		history, err := client.GetHistory(context.Background(), &api.HistoryRequest{
+			Check: args[0],
+			From:  from.Unix(),
+			To:    to.Unix(),
+		})
+		if err != nil {
+			fmt.Printf("Unable to retrieve history: %v\n", err)
+			return
+		}
+
+		fmt.Printf("%d results\n", len(history.Results))
+		for i := range history.Results {
+			r := history.Results[i]
+			line := fmt.Sprintf("%s %s", time.Unix(r.Time, 0).Format(time.DateTime), strings.ToLower(api.Status_name[int32(r.Result)]))
+			if len(r.Detail) > 0 {
+				line = fmt.Sprintf("%s (%s)", line, r.Detail)
+			}
+			fmt.Println(line)
+		}
+	},
+}
+
+// parseTime parses either an absolute time in RFC 3339 format, or a duration which is treated as being relative
+// to the current time (e.g. "2h" means two hours ago).
+func parseTime(value string) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+func init() {
+	historyCommand.Flags().StringVar(&historyFrom, "from", "24h", "Start of the time range, as an RFC 3339 time or a duration ago")
+	historyCommand.Flags().StringVar(&historyTo, "to", "0s", "End of the time range, as an RFC 3339 time or a duration ago")
+	rootCommand.AddCommand(historyCommand)
+}
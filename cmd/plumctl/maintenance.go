@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"chameth.com/goplum/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	maintenanceStart    string
+	maintenanceDuration time.Duration
+)
+
+var maintenanceCommand = &cobra.Command{
+	Use:     "maintenance",
+	Short:   "Lists all maintenance windows",
+	Args:    cobra.NoArgs,
+	PreRunE: ConnectToApi,
+	Run: func(cmd *cobra.Command, args []string) {
+		windows, err := client.GetMaintenanceWindows(context.Background(), &api.Empty{})
+		if err != nil {
+			fmt.Printf("Unable to retrieve maintenance windows: %v\n", err)
+			return
+		}
+
+		fmt.Printf("%d maintenance windows\n", len(windows.Windows))
+		for i := range windows.Windows {
+			w := windows.Windows[i]
+			var extras []string
+
+			if w.Active {
+				extras = append(extras, "*ACTIVE*")
+			}
+
+			if w.AdHoc {
+				extras = append(extras, "[ad-hoc]")
+			}
+
+			if w.Schedule != "" {
+				extras = append(extras, fmt.Sprintf("[schedule: %s]", w.Schedule))
+			}
+
+			if w.Start != 0 {
+				extras = append(extras, fmt.Sprintf("%s to %s", time.Unix(w.Start, 0).Format(time.DateTime), time.Unix(w.End, 0).Format(time.DateTime)))
+			}
+
+			fmt.Printf("%d. %s (checks: %s) %s\n", i+1, w.Name, strings.Join(w.Checks, ", "), strings.Join(extras, " "))
+		}
+	},
+}
+
+var maintenanceAddCommand = &cobra.Command{
+	Use:     "add <name> <check>...",
+	Short:   "Adds an ad-hoc maintenance window",
+	Args:    cobra.MinimumNArgs(2),
+	PreRunE: ConnectToApi,
+	Run: func(cmd *cobra.Command, args []string) {
+		start := time.Now()
+		if maintenanceStart != "" {
+			var err error
+			start, err = time.Parse(time.RFC3339, maintenanceStart)
+			if err != nil {
+				fmt.Printf("Invalid start time: %v\n", err)
+				return
+			}
+		}
+
+		window, err := client.AddMaintenanceWindow(context.Background(), &api.MaintenanceWindow{
+			Name:   args[0],
+			Checks: args[1:],
+			Start:  start.Unix(),
+			End:    start.Add(maintenanceDuration).Unix(),
+		})
+		if err != nil {
+			fmt.Printf("Unable to add maintenance window: %v\n", err)
+			return
+		}
+		fmt.Printf("Added maintenance window %s, ending at %s.\n", window.Name, time.Unix(window.End, 0).Format(time.DateTime))
+	},
+}
+
+var maintenanceRemoveCommand = &cobra.Command{
+	Use:     "remove <name>",
+	Short:   "Removes an ad-hoc maintenance window",
+	Args:    cobra.ExactArgs(1),
+	PreRunE: ConnectToApi,
+	Run: func(cmd *cobra.Command, args []string) {
+		_, err := client.RemoveMaintenanceWindow(context.Background(), &api.MaintenanceWindowName{Name: args[0]})
+		if err != nil {
+			fmt.Printf("Unable to remove maintenance window: %v\n", err)
+			return
+		}
+		fmt.Printf("Removed maintenance window %s.\n", args[0])
+	},
+}
+
+func init() {
+	maintenanceAddCommand.Flags().StringVar(&maintenanceStart, "start", "", "Start of the window as an RFC 3339 time; defaults to now")
+	maintenanceAddCommand.Flags().DurationVar(&maintenanceDuration, "duration", time.Hour, "Length of the window")
+	maintenanceCommand.AddCommand(maintenanceAddCommand)
+	maintenanceCommand.AddCommand(maintenanceRemoveCommand)
+	rootCommand.AddCommand(maintenanceCommand)
+}
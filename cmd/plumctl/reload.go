@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"chameth.com/goplum/api"
+	"github.com/spf13/cobra"
+)
+
+var reloadCommand = &cobra.Command{
+	Use:     "reload",
+	Short:   "Reload the server's configuration file",
+	Args:    cobra.NoArgs,
+	PreRunE: ConnectToApi,
+	Run: func(cmd *cobra.Command, args []string) {
+		if _, err := client.ReloadConfig(context.Background(), &api.Empty{}); err != nil {
+			fmt.Printf("Unable to reload config: %v\n", err)
+			return
+		}
+		fmt.Printf("Config reloaded.\n")
+	},
+}
+
+func init() {
+	rootCommand.AddCommand(reloadCommand)
+}
@@ -3,26 +3,43 @@ package main
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"chameth.com/goplum/api"
 	"github.com/spf13/cobra"
 )
 
+var (
+	suspendDuration time.Duration
+	suspendReason   string
+)
+
 var suspendCommand = &cobra.Command{
 	Use:     "suspend <name>",
 	Short:   "Suspend a check",
 	Args:    cobra.ExactArgs(1),
 	PreRunE: ConnectToApi,
 	Run: func(cmd *cobra.Command, args []string) {
-		check, err := client.SuspendCheck(context.Background(), &api.CheckName{Name: args[0]})
+		check, err := client.SuspendCheck(context.Background(), &api.SuspendRequest{
+			Name:     args[0],
+			Duration: int64(suspendDuration.Seconds()),
+			Reason:   suspendReason,
+		})
 		if err != nil {
 			fmt.Printf("Unable to suspend check: %v\n", err)
 			return
 		}
-		fmt.Printf("Suspended check %s.\n", check.Name)
+
+		if check.SuspendedUntil != 0 {
+			fmt.Printf("Suspended check %s until %s.\n", check.Name, time.Unix(check.SuspendedUntil, 0).Format(time.DateTime))
+		} else {
+			fmt.Printf("Suspended check %s.\n", check.Name)
+		}
 	},
 }
 
 func init() {
+	suspendCommand.Flags().DurationVar(&suspendDuration, "for", 0, "Length of time to suspend the check for; suspends indefinitely if not specified")
+	suspendCommand.Flags().StringVar(&suspendReason, "reason", "", "Reason the check is being suspended")
 	rootCommand.AddCommand(suspendCommand)
 }
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// function is a function that can be called when assigning a value in the config file. It receives the parser,
+// so it can resolve paths relative to the file being parsed, and its (string) arguments.
+type function func(p *Parser, args []string) (string, error)
+
+var functions = map[string]function{
+	"env":  envFunction,
+	"file": fileFunction,
+}
+
+// envFunction returns the value of the environment variable named in the first argument. If the variable is not
+// set, the second argument is used as a default if present, otherwise an error is returned.
+func envFunction(_ *Parser, args []string) (string, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return "", fmt.Errorf("expected a variable name and optional default, got %d arguments", len(args))
+	}
+
+	if value, ok := os.LookupEnv(args[0]); ok {
+		return value, nil
+	}
+
+	if len(args) == 2 {
+		return args[1], nil
+	}
+
+	return "", fmt.Errorf("environment variable %s is not set", args[0])
+}
+
+// fileFunction returns the contents of the file named in the first argument, with any trailing newlines removed.
+// Relative paths are resolved against the directory of the config file being parsed.
+func fileFunction(p *Parser, args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("expected a single path, got %d arguments", len(args))
+	}
+
+	path := args[0]
+	if !filepath.IsAbs(path) && p.path != "" {
+		path = filepath.Join(filepath.Dir(p.path), path)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
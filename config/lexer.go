@@ -41,6 +41,9 @@ const (
 	tokenCheck                           // check
 	tokenPlugin                          // plugin
 	tokenGroup                           // group
+	tokenInclude                         // include
+	tokenMaintenance                     // maintenance
+	tokenEscalation                      // escalation
 )
 
 var tokenNames = map[tokenClass]string{
@@ -65,14 +68,20 @@ var tokenNames = map[tokenClass]string{
 	tokenCheck:         "check keyword",
 	tokenPlugin:        "plugin keyword",
 	tokenGroup:         "group keyword",
+	tokenInclude:       "include keyword",
+	tokenMaintenance:   "maintenance keyword",
+	tokenEscalation:    "escalation keyword",
 }
 
 var keywords = map[string]tokenClass{
-	"defaults": tokenDefaults,
-	"alert":    tokenAlert,
-	"check":    tokenCheck,
-	"plugin":   tokenPlugin,
-	"group":    tokenGroup,
+	"defaults":    tokenDefaults,
+	"alert":       tokenAlert,
+	"check":       tokenCheck,
+	"plugin":      tokenPlugin,
+	"group":       tokenGroup,
+	"include":     tokenInclude,
+	"maintenance": tokenMaintenance,
+	"escalation":  tokenEscalation,
 }
 
 var booleans = map[string]bool{
@@ -1,9 +1,12 @@
 package config
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 
@@ -17,15 +20,19 @@ type Block struct {
 }
 
 type Parser struct {
-	lexer           *Lexer
-	saved           *token
-	last            *token
-	hasDefaults     bool
-	DefaultSettings map[string]any
-	AlertBlocks     []*Block
-	CheckBlocks     []*Block
-	PluginSettings  []*Block
-	GroupBlocks     []*Block
+	lexer             *Lexer
+	saved             *token
+	last              *token
+	path              string
+	files             []string
+	hasDefaults       bool
+	DefaultSettings   map[string]any
+	AlertBlocks       []*Block
+	CheckBlocks       []*Block
+	PluginSettings    []*Block
+	GroupBlocks       []*Block
+	MaintenanceBlocks []*Block
+	EscalationBlocks  []*Block
 }
 
 func NewParser(reader io.Reader) *Parser {
@@ -35,16 +42,43 @@ func NewParser(reader io.Reader) *Parser {
 	}
 }
 
+// ParseFile parses the config file at the given path. If the path is a directory, then all files within it with a
+// ".conf" extension are parsed in lexical order.
+func ParseFile(path string) (*Parser, error) {
+	p := &Parser{
+		DefaultSettings: make(map[string]any),
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		return p, p.includeMatching(filepath.Join(path, "*.conf"))
+	}
+
+	return p, p.parseFile(path)
+}
+
 func (p *Parser) Parse() error {
 	go p.lexer.Lex()
 
 	for {
-		t, err := p.take(tokenEOF, tokenError, tokenDefaults, tokenAlert, tokenCheck, tokenPlugin, tokenGroup)
+		t, err := p.take(tokenEOF, tokenError, tokenDefaults, tokenAlert, tokenCheck, tokenPlugin, tokenGroup, tokenInclude, tokenMaintenance, tokenEscalation)
 		if err != nil {
 			return err
 		}
 
 		switch t.Class {
+		case tokenInclude:
+			pattern, err := p.take(tokenString)
+			if err != nil {
+				return err
+			}
+			if err := p.include(pattern.Value.(string)); err != nil {
+				return fmt.Errorf("unable to include '%s' at line %d: %w", pattern.Value, t.Line, err)
+			}
 		case tokenDefaults:
 			if p.hasDefaults {
 				return fmt.Errorf("duplicate defaults block declared at line %d", t.Line)
@@ -81,6 +115,18 @@ func (p *Parser) Parse() error {
 				return err
 			}
 			p.GroupBlocks = append(p.GroupBlocks, block)
+		case tokenMaintenance:
+			block, err := p.parseBlockWithName(false)
+			if err != nil {
+				return err
+			}
+			p.MaintenanceBlocks = append(p.MaintenanceBlocks, block)
+		case tokenEscalation:
+			block, err := p.parseBlockWithName(false)
+			if err != nil {
+				return err
+			}
+			p.EscalationBlocks = append(p.EscalationBlocks, block)
 		case tokenError:
 			return errors.New(t.Value.(string))
 		case tokenEOF:
@@ -89,6 +135,67 @@ func (p *Parser) Parse() error {
 	}
 }
 
+// include parses all files matching the given glob pattern. Relative patterns are resolved against the directory
+// of the file currently being parsed.
+func (p *Parser) include(pattern string) error {
+	if !filepath.IsAbs(pattern) && p.path != "" {
+		pattern = filepath.Join(filepath.Dir(p.path), pattern)
+	}
+
+	if !strings.ContainsAny(pattern, "*?[\\") {
+		if _, err := os.Stat(pattern); err != nil {
+			return err
+		}
+	}
+
+	return p.includeMatching(pattern)
+}
+
+func (p *Parser) includeMatching(pattern string) error {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return err
+	}
+
+	for i := range matches {
+		if err := p.parseFile(matches[i]); err != nil {
+			return fmt.Errorf("in %s: %w", matches[i], err)
+		}
+	}
+
+	return nil
+}
+
+// parseFile parses the given file, adding its contents to those already parsed. Once the file has been
+// parsed the parser resumes from where it was in the including file.
+func (p *Parser) parseFile(path string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(p.files, abs) {
+		return fmt.Errorf("include cycle detected: %s is already being parsed", path)
+	}
+
+	// Read the whole file up-front, as the lexer may still be running if the parser bails early.
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	lexer, saved, last, current := p.lexer, p.saved, p.last, p.path
+	p.lexer, p.saved, p.last, p.path = NewLexer(bytes.NewReader(content)), nil, nil, path
+	p.files = append(p.files, abs)
+
+	defer func() {
+		p.lexer, p.saved, p.last, p.path = lexer, saved, last, current
+		p.files = p.files[:len(p.files)-1]
+	}()
+
+	return p.Parse()
+}
+
 func (p *Parser) parseBlockWithTypeAndName(allowDefaults bool) (*Block, error) {
 	kind, err := p.take(tokenIdentifier)
 	if err != nil {
@@ -192,7 +299,7 @@ func (p *Parser) parseAssignment() (any, error) {
 		return p.parseBlock(false)
 	}
 
-	n, err := p.take(tokenString, tokenDuration, tokenInt, tokenFloat, tokenBoolean, tokenArrayStart)
+	n, err := p.take(tokenString, tokenDuration, tokenInt, tokenFloat, tokenBoolean, tokenArrayStart, tokenIdentifier)
 	if err != nil {
 		return nil, err
 	}
@@ -200,11 +307,49 @@ func (p *Parser) parseAssignment() (any, error) {
 	if n.Class == tokenArrayStart {
 		p.backup()
 		return p.parseSequence(tokenArrayStart, tokenArrayEnd)
+	} else if n.Class == tokenIdentifier {
+		return p.parseFunction(n)
 	} else {
 		return n.Value, nil
 	}
 }
 
+// parseFunction parses the arguments to a function call such as `env("FOO")`, and returns the result of
+// evaluating it. All functions currently return strings.
+func (p *Parser) parseFunction(name *token) (string, error) {
+	function, ok := functions[name.Value.(string)]
+	if !ok {
+		return "", fmt.Errorf("unknown function '%s' at line %d column %d", name.Value, name.Line, name.Column)
+	}
+
+	if _, err := p.take(tokenFunctionStart); err != nil {
+		return "", err
+	}
+
+	var args []string
+	for {
+		t, err := p.take(tokenString, tokenFunctionEnd)
+		if err != nil {
+			return "", err
+		} else if t.Class == tokenFunctionEnd {
+			break
+		}
+		args = append(args, t.Value.(string))
+
+		if t, err = p.take(tokenDelimiter, tokenFunctionEnd); err != nil {
+			return "", err
+		} else if t.Class == tokenFunctionEnd {
+			break
+		}
+	}
+
+	res, err := function(p, args)
+	if err != nil {
+		return "", fmt.Errorf("error calling %s at line %d column %d: %v", name.Value, name.Line, name.Column, err)
+	}
+	return res, nil
+}
+
 func (p *Parser) parseSequence(start, end tokenClass) ([]any, error) {
 	if _, err := p.take(start); err != nil {
 		return nil, err
@@ -219,7 +364,7 @@ func (p *Parser) parseSequence(start, end tokenClass) ([]any, error) {
 		if delim {
 			wanted = []tokenClass{tokenDelimiter, end}
 		} else {
-			wanted = []tokenClass{tokenBoolean, tokenDuration, tokenFloat, tokenInt, tokenString, end}
+			wanted = []tokenClass{tokenBoolean, tokenDuration, tokenFloat, tokenInt, tokenString, tokenIdentifier, end}
 		}
 
 		t, err := p.take(wanted...)
@@ -227,6 +372,15 @@ func (p *Parser) parseSequence(start, end tokenClass) ([]any, error) {
 			return nil, err
 		}
 
+		if t.Class == tokenIdentifier {
+			// Function calls are evaluated and then treated like any other string.
+			value, err := p.parseFunction(t)
+			if err != nil {
+				return nil, err
+			}
+			t = &token{Class: tokenString, Line: t.Line, Column: t.Column, Value: value}
+		}
+
 		switch t.Class {
 		case end:
 			return values, nil
@@ -22,8 +22,15 @@ func TestParser_GoldenData(t *testing.T) {
 		"defaults_in_alert",
 		"defaults_in_plugin",
 		"group_with_defaults",
+		"maintenance",
+		"escalation",
+		"functions",
+		"function_unknown",
+		"function_missing_env",
+		"function_missing_file",
 	}
 	gold := goldie.New(t)
+	t.Setenv("GOPLUM_TEST_SID", "abc123")
 
 	for i := range tests {
 		t.Run(tests[i], func(t *testing.T) {
@@ -43,3 +50,30 @@ func TestParser_GoldenData(t *testing.T) {
 		})
 	}
 }
+
+func TestParseFile_GoldenData(t *testing.T) {
+	tests := map[string]string{
+		"include":                    "include.conf",
+		"include_cycle":              "include_cycle.conf",
+		"include_error":              "include_error.conf",
+		"include_missing":            "include_missing.conf",
+		"include_no_matches":         "include_no_matches.conf",
+		"include_duplicate_defaults": "include_duplicate_defaults.conf",
+		"directory":                  "includes/checks.d",
+	}
+	gold := goldie.New(t)
+
+	for name, file := range tests {
+		t.Run(name, func(t *testing.T) {
+			var expected any
+			parser, err := ParseFile(path.Join("testdata", file))
+			if err != nil {
+				expected = err.Error()
+			} else {
+				expected = parser
+			}
+
+			gold.AssertJson(t, fmt.Sprintf("%s.parser", name), expected)
+		})
+	}
+}
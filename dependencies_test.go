@@ -0,0 +1,104 @@
+package goplum_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"chameth.com/goplum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependencies_SuppressAlertsWhileParentFailing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goplum.conf")
+	writeConfig(t, path, `
+alert debug.sysout "debug" {}
+check debug.random "router" { percent_good = 0.0 }
+check debug.random "switch" {
+  percent_good = 0.0
+  depends_on = ["router"]
+}
+check debug.random "website" {
+  percent_good = 0.0
+  depends_on = ["switch"]
+}
+check debug.random "unrelated" { percent_good = 0.0 }
+`)
+
+	plum := goplum.NewPlum()
+	plum.RegisterPlugins(plugins)
+	require.NoError(t, plum.ReadConfig(path))
+
+	alerted := make(map[string]bool)
+	plum.AddAlertListener(func(_ string, details goplum.AlertDetails, _ error) {
+		alerted[details.Name] = true
+	})
+
+	// Settle all checks as good, then have them all fail.
+	for _, name := range []string{"router", "switch", "website", "unrelated"} {
+		check := plum.Checks[name]
+		check.State = goplum.StateGood
+		check.Settled = true
+		plum.RunCheck(check)
+		plum.RunCheck(check)
+	}
+
+	assert.Equal(t, map[string]bool{"router": true, "unrelated": true}, alerted)
+	assert.Equal(t, goplum.StateFailing, plum.Checks["switch"].State)
+	assert.Equal(t, goplum.StateFailing, plum.Checks["website"].State)
+
+	// Once the router recovers, dependents alert as normal.
+	clear(alerted)
+	plum.Checks["router"].State = goplum.StateGood
+	plum.RaiseAlerts(plum.Checks["switch"], goplum.StateGood)
+	assert.Equal(t, map[string]bool{"switch": true}, alerted)
+}
+
+func TestDependencies_SuppressRecoveryOfSuppressedFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goplum.conf")
+	writeConfig(t, path, `
+alert debug.sysout "debug" {}
+check debug.random "router" { percent_good = 0.0 }
+check debug.random "website" {
+  percent_good = 0.0
+  depends_on = ["router"]
+}
+`)
+
+	plum := goplum.NewPlum()
+	plum.RegisterPlugins(plugins)
+	require.NoError(t, plum.ReadConfig(path))
+
+	var alerted []goplum.CheckState
+	plum.AddAlertListener(func(_ string, details goplum.AlertDetails, _ error) {
+		if details.Name == "website" {
+			alerted = append(alerted, details.NewState)
+		}
+	})
+
+	router := plum.Checks["router"]
+	router.State = goplum.StateFailing
+	router.Settled = true
+
+	website := plum.Checks["website"]
+	website.AddResult(&goplum.Result{State: goplum.StateFailing})
+	website.State = goplum.StateFailing
+	website.Settled = true
+	plum.RaiseAlerts(website, goplum.StateGood)
+	assert.Empty(t, alerted)
+	assert.True(t, website.FailureSuppressed)
+
+	// The router recovers first, but nobody was told the website was failing so its recovery isn't alerted.
+	router.State = goplum.StateGood
+	website.State = goplum.StateGood
+	plum.RaiseAlerts(website, goplum.StateFailing)
+	assert.Empty(t, alerted)
+	assert.False(t, website.FailureSuppressed)
+
+	// Later failures are alerted as normal, along with their recovery.
+	website.State = goplum.StateFailing
+	plum.RaiseAlerts(website, goplum.StateGood)
+	website.State = goplum.StateGood
+	plum.RaiseAlerts(website, goplum.StateFailing)
+	assert.Equal(t, []goplum.CheckState{goplum.StateFailing, goplum.StateGood}, alerted)
+}
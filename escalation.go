@@ -0,0 +1,163 @@
+package goplum
+
+import (
+	"cmp"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"chameth.com/goplum/config"
+	"chameth.com/goplum/internal"
+)
+
+// Escalation is a policy that sends alerts to an increasing number of destinations the longer a check is failing.
+type Escalation struct {
+	Name  string
+	Steps []*EscalationStep
+}
+
+// EscalationStep is a single step in an escalation policy. Once a check has been failing for the given length of
+// time, the step's alerts are sent.
+type EscalationStep struct {
+	Name   string
+	After  time.Duration
+	Alerts []string
+}
+
+// stepsDue returns the number of steps that should have been notified once a check has been failing for the given
+// length of time.
+func (e *Escalation) stepsDue(failingFor time.Duration) int {
+	due := 0
+	for due < len(e.Steps) && e.Steps[due].After <= failingFor {
+		due++
+	}
+	return due
+}
+
+// alerts returns the alerts configured in the given range of steps.
+func (e *Escalation) alerts(from, to int) []string {
+	var res []string
+	for _, step := range e.Steps[from:to] {
+		res = append(res, step.Alerts...)
+	}
+	return res
+}
+
+func (p *Plum) addEscalations(escalations []*config.Block) error {
+	for i := range escalations {
+		name := escalations[i].Name
+		if _, ok := p.Escalations[name]; ok {
+			return fmt.Errorf("escalation defined multiple times: %s", name)
+		}
+
+		escalation := &Escalation{Name: name}
+		for stepName, settings := range escalations[i].Settings {
+			block, ok := settings.(map[string]any)
+			if !ok {
+				return fmt.Errorf("error configuring escalation %s: step %s must be a block", name, stepName)
+			}
+
+			step := &EscalationStep{Name: stepName}
+			if err := internal.DecodeSettings(&block, step); err != nil {
+				return fmt.Errorf("error configuring escalation %s: step %s: %v", name, stepName, err)
+			}
+
+			if len(step.Alerts) == 0 {
+				return fmt.Errorf("error configuring escalation %s: step %s has no alerts", name, stepName)
+			}
+
+			for a := range step.Alerts {
+				if len(p.AlertsMatching(step.Alerts[a:a+1])) == 0 {
+					return fmt.Errorf("error configuring escalation %s: step %s: no alerts match '%s'", name, stepName, step.Alerts[a])
+				}
+			}
+
+			escalation.Steps = append(escalation.Steps, step)
+		}
+
+		if len(escalation.Steps) == 0 {
+			return fmt.Errorf("error configuring escalation %s: no steps defined", name)
+		}
+
+		slices.SortFunc(escalation.Steps, func(a, b *EscalationStep) int {
+			return cmp.Or(cmp.Compare(a.After, b.After), cmp.Compare(a.Name, b.Name))
+		})
+
+		p.Escalations[name] = escalation
+	}
+
+	return nil
+}
+
+// escalationFor returns the escalation policy used by the given check, or nil if it doesn't have one.
+func (p *Plum) escalationFor(c *ScheduledCheck) *Escalation {
+	if c.Config.EscalationPolicy == "" {
+		return nil
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.Escalations[c.Config.EscalationPolicy]
+}
+
+// alertTargets returns the names of the alerts that should be sent when a check changes state (or for a reminder),
+// along with the escalation level the check will have reached once they're sent.
+//
+// Checks without an escalation policy use their alerts setting. For checks with one, reminders and recoveries
+// notify all steps that have been reached, and other changes (including new failures) notify the steps that are
+// due immediately.
+func (p *Plum) alertTargets(c *ScheduledCheck, previousState CheckState, isReminder bool) ([]string, int) {
+	escalation := p.escalationFor(c)
+	if escalation == nil {
+		return c.Config.Alerts, c.EscalationLevel
+	}
+
+	switch {
+	case isReminder:
+		return escalation.alerts(0, c.EscalationLevel), c.EscalationLevel
+	case c.State == StateFailing:
+		p.setEscalation(c, time.Now(), 0)
+		due := escalation.stepsDue(0)
+		return escalation.alerts(0, due), due
+	case previousState == StateFailing:
+		targets := escalation.alerts(0, c.EscalationLevel)
+		p.setEscalation(c, time.Time{}, 0)
+		return targets, 0
+	default:
+		return escalation.alerts(0, escalation.stepsDue(0)), c.EscalationLevel
+	}
+}
+
+// setEscalation updates the escalation state of the check.
+func (p *Plum) setEscalation(c *ScheduledCheck, failingSince time.Time, level int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	c.FailingSince = failingSince
+	c.EscalationLevel = level
+}
+
+// escalate sends alerts for any escalation steps that have become due since the check started failing. The check
+// only moves on to the new steps once the alerts have been sent, so steps aren't skipped if the alerts are
+// suppressed. Returns true if any alerts were sent.
+func (p *Plum) escalate(c *ScheduledCheck) bool {
+	escalation := p.escalationFor(c)
+	if escalation == nil || c.FailingSince.IsZero() {
+		return false
+	}
+
+	due := escalation.stepsDue(time.Since(c.FailingSince))
+	if due <= c.EscalationLevel {
+		return false
+	}
+
+	log.Printf("Escalating alerts for %s to step %s of %s", c.Name, escalation.Steps[due-1].Name, escalation.Name)
+	if !p.sendAlerts(c, c.State, true, escalation.alerts(c.EscalationLevel, due)) {
+		return false
+	}
+
+	p.setEscalation(c, c.FailingSince, due)
+	return true
+}
@@ -0,0 +1,244 @@
+package goplum_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"chameth.com/goplum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscalation_NotifiesStepsAsFailureContinues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goplum.conf")
+	writeConfig(t, path, `
+alert debug.sysout "chat" {}
+alert debug.sysout "page" {}
+alert debug.sysout "phone" {}
+
+escalation "critical" {
+  chat { alerts = ["chat"] }
+  page {
+    after = 10m
+    alerts = ["page"]
+  }
+  phone {
+    after = 30m
+    alerts = ["phone"]
+  }
+}
+
+check debug.random "website" {
+  percent_good = 0.0
+  escalation_policy = "critical"
+  reminder = 1h
+}
+`)
+
+	plum := goplum.NewPlum()
+	plum.RegisterPlugins(plugins)
+	require.NoError(t, plum.ReadConfig(path))
+
+	var alerted []string
+	plum.AddAlertListener(func(name string, _ goplum.AlertDetails, _ error) {
+		alerted = append(alerted, name)
+	})
+
+	check := plum.Checks["website"]
+	check.State = goplum.StateGood
+	check.Settled = true
+	plum.RunCheck(check)
+	alerted = nil
+	plum.RunCheck(check)
+
+	assert.Equal(t, goplum.StateFailing, check.State)
+	assert.Equal(t, []string{"chat"}, alerted)
+
+	// No further alerts until the next step is due.
+	alerted = nil
+	plum.RunCheck(check)
+	assert.Empty(t, alerted)
+
+	check.FailingSince = time.Now().Add(-11 * time.Minute)
+	plum.RunCheck(check)
+	assert.Equal(t, []string{"page"}, alerted)
+	assert.Equal(t, 2, check.EscalationLevel)
+
+	// Reminders go to all steps that have been reached so far.
+	alerted = nil
+	check.LastAlertTime = time.Now().Add(-2 * time.Hour)
+	plum.RunCheck(check)
+	assert.ElementsMatch(t, []string{"chat", "page"}, alerted)
+
+	// Recovery goes to all steps that have been reached, and resets the escalation.
+	alerted = nil
+	check.State = goplum.StateGood
+	plum.RaiseAlerts(check, goplum.StateFailing)
+	assert.ElementsMatch(t, []string{"chat", "page"}, alerted)
+	assert.Zero(t, check.EscalationLevel)
+	assert.True(t, check.FailingSince.IsZero())
+}
+
+func TestEscalation_FirstStepWaitsForItsDelay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goplum.conf")
+	writeConfig(t, path, `
+alert debug.sysout "page" {}
+
+escalation "delayed" {
+  page {
+    after = 5m
+    alerts = ["page"]
+  }
+}
+
+check debug.random "website" {
+  percent_good = 0.0
+  escalation_policy = "delayed"
+}
+`)
+
+	plum := goplum.NewPlum()
+	plum.RegisterPlugins(plugins)
+	require.NoError(t, plum.ReadConfig(path))
+
+	var alerted []string
+	plum.AddAlertListener(func(name string, _ goplum.AlertDetails, _ error) {
+		alerted = append(alerted, name)
+	})
+
+	check := plum.Checks["website"]
+	check.State = goplum.StateGood
+	check.Settled = true
+	plum.RunCheck(check)
+	alerted = nil
+	plum.RunCheck(check)
+
+	assert.Equal(t, goplum.StateFailing, check.State)
+	assert.Empty(t, alerted)
+	assert.Zero(t, check.EscalationLevel)
+
+	check.FailingSince = time.Now().Add(-6 * time.Minute)
+	plum.RunCheck(check)
+	assert.Equal(t, []string{"page"}, alerted)
+	assert.Equal(t, 1, check.EscalationLevel)
+
+	// Nobody was told about the failure if the check recovers before the first step is due.
+	alerted = nil
+	check.EscalationLevel = 0
+	check.State = goplum.StateGood
+	plum.RaiseAlerts(check, goplum.StateFailing)
+	assert.Empty(t, alerted)
+}
+
+func TestEscalation_SuppressedStepsAreNotSkipped(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goplum.conf")
+	writeConfig(t, path, `
+alert debug.sysout "chat" {}
+alert debug.sysout "page" {}
+
+escalation "critical" {
+  chat { alerts = ["chat"] }
+  page {
+    after = 10m
+    alerts = ["page"]
+  }
+}
+
+check debug.random "router" { percent_good = 0.0 }
+check debug.random "website" {
+  percent_good = 0.0
+  escalation_policy = "critical"
+  depends_on = ["router"]
+}
+`)
+
+	plum := goplum.NewPlum()
+	plum.RegisterPlugins(plugins)
+	require.NoError(t, plum.ReadConfig(path))
+
+	var alerted []string
+	plum.AddAlertListener(func(name string, details goplum.AlertDetails, _ error) {
+		if details.Name == "website" {
+			alerted = append(alerted, name)
+		}
+	})
+
+	router := plum.Checks["router"]
+	router.State = goplum.StateFailing
+	router.Settled = true
+
+	check := plum.Checks["website"]
+	check.State = goplum.StateGood
+	check.Settled = true
+	plum.RunCheck(check)
+	plum.RunCheck(check)
+
+	// The initial alert and the escalation are suppressed while the router is failing.
+	check.FailingSince = time.Now().Add(-11 * time.Minute)
+	plum.RunCheck(check)
+	assert.Empty(t, alerted)
+	assert.Zero(t, check.EscalationLevel)
+
+	// Once the router recovers, every step that is due is notified.
+	router.State = goplum.StateGood
+	plum.RunCheck(check)
+	assert.ElementsMatch(t, []string{"chat", "page"}, alerted)
+	assert.Equal(t, 2, check.EscalationLevel)
+}
+
+func TestEscalation_WarningsOnlyGoToImmediateSteps(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goplum.conf")
+	writeConfig(t, path, `
+alert debug.sysout "chat" {}
+alert debug.sysout "page" {}
+
+escalation "critical" {
+  chat { alerts = ["chat"] }
+  page {
+    after = 10m
+    alerts = ["page"]
+  }
+}
+
+escalation "delayed" {
+  page {
+    after = 5m
+    alerts = ["page"]
+  }
+}
+
+check debug.random "website" {
+  percent_good = 0.0
+  escalation_policy = "critical"
+}
+
+check debug.random "database" {
+  percent_good = 0.0
+  escalation_policy = "delayed"
+}
+`)
+
+	plum := goplum.NewPlum()
+	plum.RegisterPlugins(plugins)
+	require.NoError(t, plum.ReadConfig(path))
+
+	var alerted []string
+	plum.AddAlertListener(func(name string, _ goplum.AlertDetails, _ error) {
+		alerted = append(alerted, name)
+	})
+
+	website := plum.Checks["website"]
+	website.AddResult(&goplum.Result{State: goplum.StateWarning, Time: time.Now()})
+	website.State = goplum.StateWarning
+	plum.RaiseAlerts(website, goplum.StateGood)
+	assert.Equal(t, []string{"chat"}, alerted)
+
+	// A policy whose first step is delayed doesn't send warnings at all.
+	alerted = nil
+	database := plum.Checks["database"]
+	database.AddResult(&goplum.Result{State: goplum.StateWarning, Time: time.Now()})
+	database.State = goplum.StateWarning
+	plum.RaiseAlerts(database, goplum.StateGood)
+	assert.Empty(t, alerted)
+}
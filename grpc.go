@@ -6,11 +6,14 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"math"
 	"net"
+	"time"
 
 	"chameth.com/goplum/api"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 )
 
 var (
@@ -70,13 +73,7 @@ func (s *GrpcServer) Results(_ *api.Empty, rs api.GoPlum_ResultsServer) error {
 	)
 
 	l = func(check *ScheduledCheck, result Result) {
-		err := rs.Send(&api.Result{
-			Check:  check.Name,
-			Time:   check.LastRun.Unix(),
-			Result: s.convertState(result.State),
-			Detail: result.Detail,
-			Facts:  s.convertFacts(result.Facts),
-		})
+		err := rs.Send(s.convertResult(check.Name, check.LastRun, result))
 		if err != nil {
 			s.plum.RemoveCheckListener(l)
 			c <- err
@@ -91,9 +88,13 @@ func (s *GrpcServer) Results(_ *api.Empty, rs api.GoPlum_ResultsServer) error {
 }
 
 func (s *GrpcServer) GetChecks(_ context.Context, _ *api.Empty) (*api.CheckList, error) {
+	s.plum.mu.RLock()
+	defer s.plum.mu.RUnlock()
+
+	now := time.Now()
 	var checks []*api.Check
 	for i := range s.plum.Checks {
-		checks = append(checks, s.convertCheck(s.plum.Checks[i]))
+		checks = append(checks, s.convertCheck(s.plum.Checks[i], s.plum.maintenanceFor(i, now)))
 	}
 	return &api.CheckList{Checks: checks}, nil
 }
@@ -103,25 +104,32 @@ func (s *GrpcServer) GetCheck(_ context.Context, name *api.CheckName) (*api.Chec
 		return nil, fmt.Errorf("no name specified")
 	}
 
+	s.plum.mu.RLock()
+	defer s.plum.mu.RUnlock()
+
 	check, ok := s.plum.Checks[name.Name]
 	if ok {
-		return s.convertCheck(check), nil
+		return s.convertCheck(check, s.plum.maintenanceFor(check.Name, time.Now())), nil
 	}
 
 	return nil, fmt.Errorf("no check found with name: %s", name.Name)
 }
 
-func (s *GrpcServer) SuspendCheck(_ context.Context, name *api.CheckName) (*api.Check, error) {
-	if name == nil || len(name.Name) == 0 {
+func (s *GrpcServer) SuspendCheck(ctx context.Context, req *api.SuspendRequest) (*api.Check, error) {
+	if req == nil || len(req.Name) == 0 {
 		return nil, fmt.Errorf("no name specified")
 	}
 
-	check := s.plum.Suspend(name.Name)
+	if req.Duration < 0 {
+		return nil, fmt.Errorf("duration must not be negative")
+	}
+
+	check := s.plum.Suspend(req.Name, time.Duration(req.Duration)*time.Second, req.Reason, s.callerName(ctx))
 	if check == nil {
-		return nil, fmt.Errorf("no check found with name: %s", name.Name)
+		return nil, fmt.Errorf("no check found with name: %s", req.Name)
 	}
 
-	return s.convertCheck(check), nil
+	return s.describeCheck(check), nil
 }
 
 func (s *GrpcServer) ResumeCheck(_ context.Context, name *api.CheckName) (*api.Check, error) {
@@ -134,11 +142,152 @@ func (s *GrpcServer) ResumeCheck(_ context.Context, name *api.CheckName) (*api.C
 		return nil, fmt.Errorf("no check found with name: %s", name.Name)
 	}
 
-	return s.convertCheck(check), nil
+	return s.describeCheck(check), nil
+}
+
+func (s *GrpcServer) AcknowledgeCheck(ctx context.Context, req *api.AcknowledgeRequest) (*api.Check, error) {
+	if req == nil || len(req.Name) == 0 {
+		return nil, fmt.Errorf("no name specified")
+	}
+
+	check, err := s.plum.Acknowledge(req.Name, req.Message, s.callerName(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.describeCheck(check), nil
+}
+
+// describeCheck converts a check for use in a response, holding the lock so that it isn't modified while it is
+// being read.
+func (s *GrpcServer) describeCheck(check *ScheduledCheck) *api.Check {
+	s.plum.mu.RLock()
+	defer s.plum.mu.RUnlock()
+
+	return s.convertCheck(check, s.plum.maintenanceFor(check.Name, time.Now()))
+}
+
+func (s *GrpcServer) ReloadConfig(_ context.Context, _ *api.Empty) (*api.Empty, error) {
+	if err := s.plum.ReloadConfig(); err != nil {
+		return nil, err
+	}
+
+	return &api.Empty{}, nil
+}
+
+func (s *GrpcServer) GetHistory(_ context.Context, req *api.HistoryRequest) (*api.ResultList, error) {
+	if req == nil || len(req.Check) == 0 {
+		return nil, fmt.Errorf("no check specified")
+	}
+
+	if req.From <= 0 {
+		return nil, fmt.Errorf("no start time specified")
+	}
+
+	results, err := s.plum.QueryHistory(req.Check, time.Unix(req.From, 0), time.Unix(req.To, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*api.Result, len(results))
+	for i := range results {
+		res[i] = s.convertResult(req.Check, results[i].Time, results[i])
+	}
+	return &api.ResultList{Results: res}, nil
+}
+
+func (s *GrpcServer) GetMaintenanceWindows(_ context.Context, _ *api.Empty) (*api.MaintenanceWindowList, error) {
+	s.plum.mu.RLock()
+	defer s.plum.mu.RUnlock()
+
+	now := time.Now()
+	var windows []*api.MaintenanceWindow
+	for i := range s.plum.Maintenance {
+		windows = append(windows, s.convertMaintenanceWindow(s.plum.Maintenance[i], now))
+	}
+	return &api.MaintenanceWindowList{Windows: windows}, nil
+}
+
+func (s *GrpcServer) AddMaintenanceWindow(_ context.Context, req *api.MaintenanceWindow) (*api.MaintenanceWindow, error) {
+	if req == nil || len(req.Name) == 0 {
+		return nil, fmt.Errorf("no name specified")
+	}
+
+	start := time.Now()
+	if req.Start != 0 {
+		start = time.Unix(req.Start, 0)
+	}
+
+	window := &MaintenanceWindow{
+		Name:     req.Name,
+		Checks:   req.Checks,
+		Start:    start.Format(time.RFC3339),
+		Duration: time.Unix(req.End, 0).Sub(start),
+	}
+
+	if err := s.plum.AddMaintenanceWindow(window); err != nil {
+		return nil, err
+	}
+
+	return s.convertMaintenanceWindow(window, time.Now()), nil
 }
 
-func (s *GrpcServer) convertCheck(check *ScheduledCheck) *api.Check {
-	return &api.Check{
+func (s *GrpcServer) RemoveMaintenanceWindow(_ context.Context, name *api.MaintenanceWindowName) (*api.Empty, error) {
+	if name == nil || len(name.Name) == 0 {
+		return nil, fmt.Errorf("no name specified")
+	}
+
+	if err := s.plum.RemoveMaintenanceWindow(name.Name); err != nil {
+		return nil, err
+	}
+
+	return &api.Empty{}, nil
+}
+
+// callerName returns the common name of the client certificate used to make the request, if available.
+func (s *GrpcServer) callerName(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.PeerCertificates) == 0 {
+		return ""
+	}
+
+	return info.State.PeerCertificates[0].Subject.CommonName
+}
+
+func (s *GrpcServer) convertMaintenanceWindow(window *MaintenanceWindow, now time.Time) *api.MaintenanceWindow {
+	res := &api.MaintenanceWindow{
+		Name:     window.Name,
+		Checks:   window.Checks,
+		Schedule: window.Schedule,
+		Active:   window.Active(now),
+		AdHoc:    window.AdHoc,
+	}
+
+	if start, end := window.Current(now); !start.IsZero() {
+		res.Start = start.Unix()
+		res.End = end.Unix()
+	}
+
+	return res
+}
+
+func (s *GrpcServer) convertResult(check string, t time.Time, result Result) *api.Result {
+	return &api.Result{
+		Check:  check,
+		Time:   t.Unix(),
+		Result: s.convertState(result.State),
+		Detail: result.Detail,
+		Facts:  s.convertFacts(result.Facts),
+	}
+}
+
+func (s *GrpcServer) convertCheck(check *ScheduledCheck, maintenance *MaintenanceWindow) *api.Check {
+	res := &api.Check{
 		Name:      check.Name,
 		Type:      check.Type,
 		LastRun:   check.LastRun.Unix(),
@@ -146,6 +295,27 @@ func (s *GrpcServer) convertCheck(check *ScheduledCheck) *api.Check {
 		State:     s.convertState(check.State),
 		Suspended: check.Suspended,
 	}
+
+	if maintenance != nil {
+		res.Maintenance = maintenance.Name
+	}
+
+	if check.Suspended {
+		res.SuspendedReason = check.SuspendedReason
+		res.SuspendedBy = check.SuspendedBy
+		if !check.SuspendedUntil.IsZero() {
+			res.SuspendedUntil = check.SuspendedUntil.Unix()
+		}
+	}
+
+	if check.Acknowledged {
+		res.Acknowledged = true
+		res.AcknowledgedAt = check.AcknowledgedAt.Unix()
+		res.AcknowledgedBy = check.AcknowledgedBy
+		res.AcknowledgedMessage = check.AcknowledgedMessage
+	}
+
+	return res
 }
 
 func (s *GrpcServer) convertState(state CheckState) api.Status {
@@ -154,6 +324,8 @@ func (s *GrpcServer) convertState(state CheckState) api.Status {
 		return api.Status_INDETERMINATE
 	case StateGood:
 		return api.Status_GOOD
+	case StateWarning:
+		return api.Status_WARNING
 	case StateFailing:
 		return api.Status_FAILING
 	default:
@@ -173,10 +345,21 @@ func (s *GrpcServer) convertFacts(facts map[Fact]any) []*api.Fact {
 }
 
 func (s *GrpcServer) convertFactValue(i any) api.FactValue {
-	if v, ok := i.(int64); ok {
+	switch v := i.(type) {
+	case int64:
 		return &api.Fact_Int{Int: v}
-	}
-	if v, ok := i.(string); ok {
+	case int:
+		return &api.Fact_Int{Int: int64(v)}
+	case time.Duration:
+		return &api.Fact_Int{Int: int64(v)}
+	case float64:
+		// Numbers that have been through JSON (e.g. from the history store) are decoded as floats, so integral
+		// values are sent as ints to match the facts they originally came from.
+		if v == math.Trunc(v) && math.Abs(v) < math.MaxInt64 {
+			return &api.Fact_Int{Int: int64(v)}
+		}
+		return &api.Fact_Double{Double: v}
+	case string:
 		return &api.Fact_Str{Str: v}
 	}
 	return nil
@@ -0,0 +1,74 @@
+package goplum
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"chameth.com/goplum/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGrpcServer_ConvertFactValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected api.FactValue
+	}{
+		{"Int", 42, &api.Fact_Int{Int: 42}},
+		{"Int64", int64(42), &api.Fact_Int{Int: 42}},
+		{"Duration", 1500 * time.Millisecond, &api.Fact_Int{Int: 1500000000}},
+		{"String", "ok", &api.Fact_Str{Str: "ok"}},
+		{"IntegralFloat", 42.0, &api.Fact_Int{Int: 42}},
+		{"FractionalFloat", 12.5, &api.Fact_Double{Double: 12.5}},
+		{"NegativeFractionalFloat", -0.25, &api.Fact_Double{Double: -0.25}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, (&GrpcServer{}).convertFactValue(test.value))
+		})
+	}
+}
+
+func TestGrpcServer_ConvertResult_FractionalFacts(t *testing.T) {
+	// Round-trip the result through JSON, as the history store does, before converting it.
+	b, err := json.Marshal(Result{State: StateGood, Facts: map[Fact]any{"loss": 33.3, "rtt": 2 * time.Millisecond}})
+	require.NoError(t, err)
+
+	var result Result
+	require.NoError(t, json.Unmarshal(b, &result))
+
+	// Check the facts survive being sent over the wire.
+	b, err = proto.Marshal((&GrpcServer{}).convertResult("ping", time.Now(), result))
+	require.NoError(t, err)
+
+	converted := &api.Result{}
+	require.NoError(t, proto.Unmarshal(b, converted))
+
+	facts := make(map[string]*api.Fact)
+	for _, f := range converted.Facts {
+		facts[f.Name] = f
+	}
+	assert.Equal(t, 33.3, facts["loss"].GetDouble())
+	assert.Equal(t, int64(2*time.Millisecond), facts["rtt"].GetInt())
+}
+
+func TestGrpcServer_GetHistoryRequiresStart(t *testing.T) {
+	store, err := NewFileHistoryStore(t.TempDir(), 0)
+	require.NoError(t, err)
+	defer store.Close()
+
+	plum := NewPlum()
+	plum.SetHistoryStore(store)
+	server := &GrpcServer{plum: plum}
+
+	_, err = server.GetHistory(context.Background(), &api.HistoryRequest{Check: "test", To: time.Now().Unix()})
+	assert.ErrorContains(t, err, "no start time specified")
+
+	_, err = server.GetHistory(context.Background(), &api.HistoryRequest{Check: "test", From: time.Now().Add(-time.Hour).Unix(), To: time.Now().Unix()})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,230 @@
+package goplum
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	historyPath      = flag.String("history-path", "", "Path to a directory to store check history in; history is not persisted if empty")
+	historyRetention = flag.Duration("history-retention", 30*24*time.Hour, "Length of time to keep check history for")
+)
+
+// HistoryStore persists the results of checks so they can be queried later.
+type HistoryStore interface {
+	// Record stores the result of the named check.
+	Record(check string, result Result) error
+	// Query returns all results for the named check that occurred between from and to (inclusive), oldest first.
+	Query(check string, from, to time.Time) ([]Result, error)
+	// Close releases any resources held by the store.
+	Close() error
+}
+
+// historyRecord is the representation of a result in a FileHistoryStore.
+type historyRecord struct {
+	Check  string `json:"check"`
+	Result Result `json:"result"`
+}
+
+const historyFileFormat = "2006-01-02"
+const historyFileSuffix = ".jsonl"
+
+// maxHistoryQuerySpan is the longest range of time that can be queried at once.
+const maxHistoryQuerySpan = 366 * 24 * time.Hour
+
+// FileHistoryStore is a HistoryStore that appends results to a file on disk. A new file is started each day (UTC),
+// and files older than the retention period are deleted.
+type FileHistoryStore struct {
+	dir       string
+	retention time.Duration
+
+	mu   sync.Mutex
+	day  string
+	file *os.File
+}
+
+// NewFileHistoryStore creates a new FileHistoryStore that stores results in the given directory, creating it if
+// necessary. Results older than the retention period are removed; a retention of 0 keeps results forever.
+func NewFileHistoryStore(dir string, retention time.Duration) (*FileHistoryStore, error) {
+	if err := os.MkdirAll(dir, os.FileMode(0700)); err != nil {
+		return nil, err
+	}
+
+	s := &FileHistoryStore{
+		dir:       dir,
+		retention: retention,
+	}
+
+	return s, s.prune()
+}
+
+func (s *FileHistoryStore) Record(check string, result Result) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if result.Time.IsZero() {
+		result.Time = time.Now()
+	}
+
+	day := result.Time.UTC().Format(historyFileFormat)
+	if day != s.day {
+		if err := s.rotate(day); err != nil {
+			return err
+		}
+	}
+
+	b, err := json.Marshal(historyRecord{Check: check, Result: result})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.file.Write(append(b, '\n'))
+	return err
+}
+
+// rotate closes the current file (if any) and opens the file for the given day. The caller must hold the lock.
+func (s *FileHistoryStore) rotate(day string) error {
+	if s.file != nil {
+		if err := s.file.Close(); err != nil {
+			log.Printf("Unable to close history file: %v", err)
+		}
+		s.file = nil
+	}
+
+	f, err := os.OpenFile(filepath.Join(s.dir, day+historyFileSuffix), os.O_WRONLY|os.O_CREATE|os.O_APPEND, os.FileMode(0600))
+	if err != nil {
+		return err
+	}
+
+	s.file = f
+	s.day = day
+	return s.prune()
+}
+
+// prune removes any files that are entirely outside the retention period.
+func (s *FileHistoryStore) prune() error {
+	if s.retention <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-s.retention).UTC()
+	for i := range entries {
+		name, ok := strings.CutSuffix(entries[i].Name(), historyFileSuffix)
+		if !ok {
+			continue
+		}
+
+		day, err := time.Parse(historyFileFormat, name)
+		if err != nil {
+			continue
+		}
+
+		if day.AddDate(0, 0, 1).Before(cutoff) {
+			if err := os.Remove(filepath.Join(s.dir, entries[i].Name())); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Query returns results from the history files covering the given range. The start of the range is moved forward to
+// the retention period if necessary, and ranges longer than maxHistoryQuerySpan are rejected. Only days that have a
+// file on disk are read.
+func (s *FileHistoryStore) Query(check string, from, to time.Time) ([]Result, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("end of range is before the start")
+	}
+
+	if cutoff := time.Now().Add(-s.retention); s.retention > 0 && from.Before(cutoff) {
+		from = cutoff
+	}
+
+	if to.Sub(from) > maxHistoryQuerySpan {
+		return nil, fmt.Errorf("range must not be longer than %d days", maxHistoryQuerySpan/(24*time.Hour))
+	}
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	first := from.UTC().Format(historyFileFormat)
+	last := to.UTC().Format(historyFileFormat)
+	for i := range entries {
+		// Entries are sorted by name, and the date format sorts chronologically.
+		day, ok := strings.CutSuffix(entries[i].Name(), historyFileSuffix)
+		if !ok || day < first || day > last {
+			continue
+		}
+
+		if _, err := time.Parse(historyFileFormat, day); err != nil {
+			continue
+		}
+
+		dayResults, err := s.read(day, check, from, to)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, dayResults...)
+	}
+
+	return results, nil
+}
+
+// read returns all results for the given check in the file for the given day, that fall within the given range.
+func (s *FileHistoryStore) read(day, check string, from, to time.Time) ([]Result, error) {
+	f, err := os.Open(filepath.Join(s.dir, day+historyFileSuffix))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var results []Result
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		record := historyRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			// A partially written line (e.g. if we crashed) shouldn't prevent the rest of the history being read.
+			continue
+		}
+
+		if record.Check == check && !record.Result.Time.Before(from) && !record.Result.Time.After(to) {
+			results = append(results, record.Result)
+		}
+	}
+
+	return results, scanner.Err()
+}
+
+func (s *FileHistoryStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+
+	err := s.file.Close()
+	s.file = nil
+	s.day = ""
+	return err
+}
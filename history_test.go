@@ -0,0 +1,161 @@
+package goplum
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestResultHistory_State(t *testing.T) {
+	thresholds := map[CheckState]int{
+		StateGood:    2,
+		StateWarning: 3,
+		StateFailing: 2,
+	}
+
+	tests := []struct {
+		name     string
+		states   []CheckState
+		expected CheckState
+	}{
+		{"Empty", nil, StateIndeterminate},
+		{"GoodThreshold", []CheckState{StateGood, StateGood}, StateGood},
+		{"BelowWarningThreshold", []CheckState{StateWarning, StateWarning, StateGood}, StateIndeterminate},
+		{"WarningThreshold", []CheckState{StateWarning, StateWarning, StateWarning, StateGood}, StateWarning},
+		{"FailingAfterWarnings", []CheckState{StateFailing, StateFailing, StateWarning, StateWarning}, StateFailing},
+		{"ThresholdReachedFurtherBack", []CheckState{StateWarning, StateFailing, StateGood, StateGood}, StateGood},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			history := ResultHistory{}
+			for i := range test.states {
+				history[i] = &Result{State: test.states[i]}
+			}
+
+			if actual := history.State(thresholds); actual != test.expected {
+				t.Errorf("Expected state %s, got %s", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCheckState_JSON(t *testing.T) {
+	for _, state := range []CheckState{StateIndeterminate, StateGood, StateWarning, StateFailing} {
+		b, err := json.Marshal(state)
+		if err != nil {
+			t.Fatalf("Unable to marshal %s: %v", state, err)
+		}
+
+		var actual CheckState
+		if err := json.Unmarshal(b, &actual); err != nil {
+			t.Fatalf("Unable to unmarshal %s: %v", b, err)
+		}
+
+		if actual != state {
+			t.Errorf("Expected %s to round-trip, got %s", state, actual)
+		}
+	}
+}
+
+func TestFileHistoryStore_RecordAndQuery(t *testing.T) {
+	store, err := NewFileHistoryStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("Unable to create store: %v", err)
+	}
+	defer store.Close()
+
+	base := time.Date(2026, 1, 1, 23, 59, 0, 0, time.UTC)
+	for i := range 4 {
+		result := FailingResult("attempt %d", i)
+		result.Time = base.Add(time.Duration(i) * time.Minute)
+		result.Facts = map[Fact]any{CheckTime: time.Second}
+		if err := store.Record("test", result); err != nil {
+			t.Fatalf("Unable to record result: %v", err)
+		}
+		if err := store.Record("other", GoodResult()); err != nil {
+			t.Fatalf("Unable to record result: %v", err)
+		}
+	}
+
+	results, err := store.Query("test", base.Add(time.Minute), base.Add(2*time.Minute))
+	if err != nil {
+		t.Fatalf("Unable to query results: %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+
+	for i, detail := range []string{"attempt 1", "attempt 2"} {
+		if results[i].Detail != detail || results[i].State != StateFailing {
+			t.Errorf("Unexpected result %d: %v", i, results[i])
+		}
+		if results[i].Facts[CheckTime] != float64(time.Second) {
+			t.Errorf("Unexpected facts for result %d: %v", i, results[i].Facts)
+		}
+	}
+}
+
+func TestFileHistoryStore_QueryLimitsRange(t *testing.T) {
+	unlimited, err := NewFileHistoryStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("Unable to create store: %v", err)
+	}
+	defer unlimited.Close()
+
+	if _, err := unlimited.Query("test", time.Unix(0, 0), time.Now()); err == nil {
+		t.Errorf("Expected an error when querying since 1970")
+	}
+
+	store, err := NewFileHistoryStore(t.TempDir(), 5*24*time.Hour)
+	if err != nil {
+		t.Fatalf("Unable to create store: %v", err)
+	}
+	defer store.Close()
+
+	result := GoodResult()
+	result.Time = time.Now().Add(-time.Hour)
+	if err := store.Record("test", result); err != nil {
+		t.Fatalf("Unable to record result: %v", err)
+	}
+
+	// The start of the range is moved forward to the retention period.
+	results, err := store.Query("test", time.Unix(0, 0), time.Now())
+	if err != nil {
+		t.Fatalf("Unable to query results: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Errorf("Expected 1 result, got %d", len(results))
+	}
+}
+
+func TestFileHistoryStore_RemovesOldFiles(t *testing.T) {
+	dir := t.TempDir()
+	old := filepath.Join(dir, time.Now().AddDate(0, 0, -10).UTC().Format(historyFileFormat)+historyFileSuffix)
+	recent := filepath.Join(dir, time.Now().AddDate(0, 0, -2).UTC().Format(historyFileFormat)+historyFileSuffix)
+	unrelated := filepath.Join(dir, "notes.txt")
+	for _, f := range []string{old, recent, unrelated} {
+		if err := os.WriteFile(f, nil, 0600); err != nil {
+			t.Fatalf("Unable to create file: %v", err)
+		}
+	}
+
+	store, err := NewFileHistoryStore(dir, 5*24*time.Hour)
+	if err != nil {
+		t.Fatalf("Unable to create store: %v", err)
+	}
+	defer store.Close()
+
+	if _, err := os.Stat(old); !os.IsNotExist(err) {
+		t.Errorf("Expected old history file to be removed")
+	}
+	for _, f := range []string{recent, unrelated} {
+		if _, err := os.Stat(f); err != nil {
+			t.Errorf("Expected %s to be kept: %v", f, err)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domRestricted and dowRestricted track whether the fields were specified, as if both are specified a time
+	// only needs to match one of them.
+	domRestricted, dowRestricted bool
+}
+
+var cronShortcuts = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var dayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// ParseCron parses a standard five-field cron expression ("minute hour day-of-month month day-of-week").
+// Fields may contain wildcards, lists, ranges and steps (e.g. "*/15", "1-5", "mon,wed,fri"), and month and
+// day names may be used. The shortcuts "@hourly", "@daily", "@weekly", "@monthly" and "@yearly" are also supported.
+func ParseCron(expr string) (*Schedule, error) {
+	if shortcut, ok := cronShortcuts[strings.ToLower(strings.TrimSpace(expr))]; ok {
+		expr = shortcut
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression '%s': expected 5 fields, got %d", expr, len(fields))
+	}
+
+	s := &Schedule{
+		// As in Vixie cron, fields starting with "*" (such as "*/2") don't count as restricted.
+		domRestricted: !strings.HasPrefix(fields[2], "*"),
+		dowRestricted: !strings.HasPrefix(fields[4], "*"),
+	}
+
+	var err error
+	if s.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("invalid minute in cron expression '%s': %v", expr, err)
+	}
+	if s.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("invalid hour in cron expression '%s': %v", expr, err)
+	}
+	if s.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("invalid day of month in cron expression '%s': %v", expr, err)
+	}
+	if s.month, err = parseCronField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("invalid month in cron expression '%s': %v", expr, err)
+	}
+	if s.dow, err = parseCronField(fields[4], 0, 7, dayNames); err != nil {
+		return nil, fmt.Errorf("invalid day of week in cron expression '%s': %v", expr, err)
+	}
+
+	// Both 0 and 7 mean Sunday.
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+
+	return s, nil
+}
+
+// parseCronField parses a single field of a cron expression into a bitmask of the values it matches.
+func parseCronField(field string, min, max int, names map[string]int) (uint64, error) {
+	var res uint64
+
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step '%s'", stepPart)
+			}
+		}
+
+		start, end := min, max
+		if rangePart != "*" {
+			first, last, isRange := strings.Cut(rangePart, "-")
+
+			var err error
+			if start, err = parseCronValue(first, min, max, names); err != nil {
+				return 0, err
+			}
+
+			if isRange {
+				if end, err = parseCronValue(last, min, max, names); err != nil {
+					return 0, err
+				}
+			} else if !hasStep {
+				end = start
+			}
+
+			if start > end {
+				return 0, fmt.Errorf("invalid range '%s'", rangePart)
+			}
+		}
+
+		for i := start; i <= end; i += step {
+			res |= 1 << i
+		}
+	}
+
+	return res, nil
+}
+
+func parseCronValue(value string, min, max int, names map[string]int) (int, error) {
+	if n, ok := names[strings.ToLower(value)]; ok {
+		return n, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min || n > max {
+		return 0, fmt.Errorf("invalid value '%s': must be between %d and %d", value, min, max)
+	}
+	return n, nil
+}
+
+// maxCronSearch limits how far Next and Prev will search for a matching time, so that impossible
+// schedules (such as the 31st of February) don't loop forever.
+const maxCronSearch = 5 * 366 * 24 * time.Hour
+
+// Next returns the first time matching the schedule that is strictly after the given time. If no such time exists
+// within the next five years, the zero time is returned.
+func (s *Schedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.Add(maxCronSearch)
+
+	for t.Before(limit) {
+		if !s.matches(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		} else if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		} else if !s.matches(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		} else if !s.matches(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+		} else {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// Prev returns the latest time matching the schedule that is at or before the given time. If no such time exists
+// within the previous five years, the zero time is returned.
+func (s *Schedule) Prev(before time.Time) time.Time {
+	t := before.Truncate(time.Minute)
+	limit := before.Add(-maxCronSearch)
+
+	for t.After(limit) {
+		if !s.matches(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()).Add(-time.Minute)
+		} else if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).Add(-time.Minute)
+		} else if !s.matches(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location()).Add(-time.Minute)
+		} else if !s.matches(s.minute, t.Minute()) {
+			t = t.Add(-time.Minute)
+		} else {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (s *Schedule) matches(field uint64, value int) bool {
+	return field&(1<<value) != 0
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dom := s.matches(s.dom, t.Day())
+	dow := s.matches(s.dow, int(t.Weekday()))
+
+	if s.domRestricted && s.dowRestricted {
+		return dom || dow
+	}
+	return dom && dow
+}
@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSchedule_Next(t *testing.T) {
+	base := time.Date(2026, 10, 14, 10, 30, 15, 0, time.UTC) // A Wednesday
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2026, 10, 14, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 10, 14, 10, 45, 0, 0, time.UTC)},
+		{"30 2 * * *", time.Date(2026, 10, 15, 2, 30, 0, 0, time.UTC)},
+		{"30 2 * * mon-fri", time.Date(2026, 10, 15, 2, 30, 0, 0, time.UTC)},
+		{"0 9 * * sat,sun", time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 jan *", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 13 * fri", time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)},
+		{"0 0 */2 * mon", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * */2", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 29 2 *", time.Date(2028, 2, 29, 12, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 10, 14, 11, 0, 0, 0, time.UTC)},
+		{"0 0 31 2 *", time.Time{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			s, err := ParseCron(test.expr)
+			if err != nil {
+				t.Fatalf("Unable to parse expression: %v", err)
+			}
+
+			if actual := s.Next(base); !actual.Equal(test.expected) {
+				t.Errorf("Expected next time of %s, got %s", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSchedule_Prev(t *testing.T) {
+	base := time.Date(2026, 10, 14, 10, 30, 15, 0, time.UTC) // A Wednesday
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2026, 10, 14, 10, 30, 0, 0, time.UTC)},
+		{"30 2 * * *", time.Date(2026, 10, 14, 2, 30, 0, 0, time.UTC)},
+		{"30 2 * * sat", time.Date(2026, 10, 10, 2, 30, 0, 0, time.UTC)},
+		{"45 23 1 * *", time.Date(2026, 10, 1, 23, 45, 0, 0, time.UTC)},
+		{"0 0 1 jan *", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			s, err := ParseCron(test.expr)
+			if err != nil {
+				t.Fatalf("Unable to parse expression: %v", err)
+			}
+
+			if actual := s.Prev(base); !actual.Equal(test.expected) {
+				t.Errorf("Expected previous time of %s, got %s", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseCron_Errors(t *testing.T) {
+	tests := []string{
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * foo *",
+	}
+
+	for _, expr := range tests {
+		t.Run(expr, func(t *testing.T) {
+			if _, err := ParseCron(expr); err == nil {
+				t.Errorf("Expected an error parsing '%s'", expr)
+			}
+		})
+	}
+}
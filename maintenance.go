@@ -0,0 +1,213 @@
+package goplum
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"chameth.com/goplum/config"
+	"chameth.com/goplum/internal"
+)
+
+// MaintenanceWindow describes a period of time in which checks are suspended. Windows either recur according to a
+// cron schedule, or occur once at a fixed start time.
+type MaintenanceWindow struct {
+	Name     string
+	Checks   []string
+	Schedule string
+	Start    string
+	Duration time.Duration
+
+	// AdHoc indicates the window was created at runtime (e.g. via the API) rather than in the config file.
+	AdHoc bool `config:"-"`
+
+	schedule *internal.Schedule
+	start    time.Time
+	checks   *regexp.Regexp
+}
+
+// maintenanceTimeFormats are the formats accepted for the start time of one-off windows.
+var maintenanceTimeFormats = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04"}
+
+func (m *MaintenanceWindow) Validate() error {
+	if len(m.Checks) == 0 {
+		return fmt.Errorf("no checks specified")
+	}
+	m.checks = regexpForWildcards(m.Checks)
+
+	if m.Duration <= 0 {
+		return fmt.Errorf("duration must be greater than zero")
+	}
+
+	if m.Schedule == "" && m.Start == "" {
+		return fmt.Errorf("either a schedule or a start time must be specified")
+	}
+
+	if m.Schedule != "" && m.Start != "" {
+		return fmt.Errorf("only one of schedule and start may be specified")
+	}
+
+	if m.Schedule != "" {
+		schedule, err := internal.ParseCron(m.Schedule)
+		if err != nil {
+			return err
+		}
+		m.schedule = schedule
+		return nil
+	}
+
+	for _, format := range maintenanceTimeFormats {
+		if start, err := time.ParseInLocation(format, m.Start, time.Local); err == nil {
+			m.start = start
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid start time '%s': must be in the format 'YYYY-MM-DD HH:MM' or RFC 3339", m.Start)
+}
+
+// Current returns the start and end of the window that is in effect at the given time, or, if the window is not
+// in effect, the next time it will be. Zero times are returned if the window will never occur again.
+func (m *MaintenanceWindow) Current(now time.Time) (time.Time, time.Time) {
+	if m.schedule == nil {
+		if now.Before(m.start.Add(m.Duration)) {
+			return m.start, m.start.Add(m.Duration)
+		}
+		return time.Time{}, time.Time{}
+	}
+
+	// Find the first occurrence that hasn't finished by now; if it has started, it's in effect.
+	start := m.schedule.Next(now.Add(-m.Duration))
+	if start.IsZero() {
+		return time.Time{}, time.Time{}
+	}
+	return start, start.Add(m.Duration)
+}
+
+// Active determines whether the window is in effect at the given time.
+func (m *MaintenanceWindow) Active(now time.Time) bool {
+	start, end := m.Current(now)
+	return !start.After(now) && now.Before(end)
+}
+
+// Expired determines whether the window has finished and will not occur again.
+func (m *MaintenanceWindow) Expired(now time.Time) bool {
+	_, end := m.Current(now)
+	return end.IsZero()
+}
+
+// Covers determines whether the window applies to the check with the given name. The window must have been
+// validated first.
+func (m *MaintenanceWindow) Covers(check string) bool {
+	return m.checks.MatchString(check)
+}
+
+func (p *Plum) addMaintenanceWindows(windows []*config.Block) error {
+	for i := range windows {
+		if _, ok := p.Maintenance[windows[i].Name]; ok {
+			return fmt.Errorf("maintenance window defined multiple times: %s", windows[i].Name)
+		}
+
+		window := &MaintenanceWindow{
+			Name: windows[i].Name,
+		}
+
+		if err := internal.DecodeSettings(&windows[i].Settings, window); err != nil {
+			return fmt.Errorf("error configuring maintenance window %s: %v", windows[i].Name, err)
+		}
+
+		if err := window.Validate(); err != nil {
+			return fmt.Errorf("error configuring maintenance window %s: %v", windows[i].Name, err)
+		}
+
+		p.Maintenance[windows[i].Name] = window
+	}
+
+	return nil
+}
+
+// AddMaintenanceWindow adds a new ad-hoc maintenance window. Ad-hoc windows are kept when the config is reloaded,
+// and are removed automatically once they have finished.
+func (p *Plum) AddMaintenanceWindow(window *MaintenanceWindow) error {
+	if window.Name == "" {
+		return fmt.Errorf("no name specified")
+	}
+
+	if err := window.Validate(); err != nil {
+		return err
+	}
+
+	if window.Expired(time.Now()) {
+		return fmt.Errorf("maintenance window has already finished")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.Maintenance[window.Name]; ok {
+		return fmt.Errorf("a maintenance window named %s already exists", window.Name)
+	}
+
+	window.AdHoc = true
+	p.Maintenance[window.Name] = window
+	log.Printf("Maintenance window %s added for checks %v", window.Name, window.Checks)
+
+	// Wake the scheduler so it doesn't run any checks that are now in maintenance.
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// RemoveMaintenanceWindow removes the ad-hoc maintenance window with the given name. Windows defined in the config
+// file cannot be removed.
+func (p *Plum) RemoveMaintenanceWindow(name string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	window, ok := p.Maintenance[name]
+	if !ok {
+		return fmt.Errorf("no maintenance window found with name: %s", name)
+	}
+
+	if !window.AdHoc {
+		return fmt.Errorf("maintenance window %s is defined in the config file and cannot be removed", name)
+	}
+
+	delete(p.Maintenance, name)
+	log.Printf("Maintenance window %s removed", name)
+	return nil
+}
+
+// InMaintenance returns the maintenance window that currently applies to the named check, or nil if there is none.
+func (p *Plum) InMaintenance(check string) *MaintenanceWindow {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.maintenanceFor(check, time.Now())
+}
+
+// maintenanceFor returns the maintenance window that applies to the named check at the given time, if any. The
+// caller must hold the lock.
+func (p *Plum) maintenanceFor(check string, now time.Time) *MaintenanceWindow {
+	for _, window := range p.Maintenance {
+		if window.Active(now) && window.Covers(check) {
+			return window
+		}
+	}
+	return nil
+}
+
+// pruneMaintenanceWindows removes any ad-hoc maintenance windows that have finished. The caller must hold the
+// write lock.
+func (p *Plum) pruneMaintenanceWindows(now time.Time) {
+	for name, window := range p.Maintenance {
+		if window.AdHoc && window.Expired(now) {
+			log.Printf("Maintenance window %s has finished", name)
+			delete(p.Maintenance, name)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package goplum_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"chameth.com/goplum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceWindow_Active(t *testing.T) {
+	recurring := &goplum.MaintenanceWindow{Checks: []string{"*"}, Schedule: "0 2 * * *", Duration: 2 * time.Hour}
+	require.NoError(t, recurring.Validate())
+
+	oneOff := &goplum.MaintenanceWindow{Checks: []string{"*"}, Start: "2026-11-01T22:00:00Z", Duration: 4 * time.Hour}
+	require.NoError(t, oneOff.Validate())
+
+	tests := []struct {
+		name     string
+		window   *goplum.MaintenanceWindow
+		time     time.Time
+		active   bool
+		expired  bool
+		startsAt time.Time
+	}{
+		{"RecurringBefore", recurring, time.Date(2026, 10, 14, 1, 59, 0, 0, time.Local), false, false, time.Date(2026, 10, 14, 2, 0, 0, 0, time.Local)},
+		{"RecurringStart", recurring, time.Date(2026, 10, 14, 2, 0, 0, 0, time.Local), true, false, time.Date(2026, 10, 14, 2, 0, 0, 0, time.Local)},
+		{"RecurringDuring", recurring, time.Date(2026, 10, 14, 3, 59, 0, 0, time.Local), true, false, time.Date(2026, 10, 14, 2, 0, 0, 0, time.Local)},
+		{"RecurringAfter", recurring, time.Date(2026, 10, 14, 4, 0, 0, 0, time.Local), false, false, time.Date(2026, 10, 15, 2, 0, 0, 0, time.Local)},
+		{"OneOffBefore", oneOff, time.Date(2026, 11, 1, 21, 0, 0, 0, time.UTC), false, false, time.Date(2026, 11, 1, 22, 0, 0, 0, time.UTC)},
+		{"OneOffDuring", oneOff, time.Date(2026, 11, 2, 1, 0, 0, 0, time.UTC), true, false, time.Date(2026, 11, 1, 22, 0, 0, 0, time.UTC)},
+		{"OneOffAfter", oneOff, time.Date(2026, 11, 2, 2, 0, 0, 0, time.UTC), false, true, time.Time{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			start, _ := test.window.Current(test.time)
+			assert.True(t, test.startsAt.Equal(start), "expected start of %s, got %s", test.startsAt, start)
+			assert.Equal(t, test.active, test.window.Active(test.time))
+			assert.Equal(t, test.expired, test.window.Expired(test.time))
+		})
+	}
+}
+
+func TestMaintenanceWindow_SuppressesAlerts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goplum.conf")
+	writeConfig(t, path, `
+alert debug.sysout "debug" {}
+check debug.random "db-primary" { percent_good = 1.0 }
+check debug.random "web" { percent_good = 1.0 }
+`)
+
+	plum := goplum.NewPlum()
+	plum.RegisterPlugins(plugins)
+	require.NoError(t, plum.ReadConfig(path))
+
+	var alerted []string
+	plum.AddAlertListener(func(_ string, details goplum.AlertDetails, _ error) {
+		alerted = append(alerted, details.Name)
+	})
+
+	require.NoError(t, plum.AddMaintenanceWindow(&goplum.MaintenanceWindow{
+		Name:     "upgrade",
+		Checks:   []string{"db-*"},
+		Start:    time.Now().Add(-time.Minute).Format(time.RFC3339),
+		Duration: time.Hour,
+	}))
+
+	assert.NotNil(t, plum.InMaintenance("db-primary"))
+	assert.Nil(t, plum.InMaintenance("web"))
+
+	plum.RunCheck(plum.Checks["db-primary"])
+	plum.RunCheck(plum.Checks["web"])
+	plum.RaiseAlerts(plum.Checks["db-primary"], goplum.StateFailing)
+	plum.RaiseAlerts(plum.Checks["web"], goplum.StateFailing)
+	assert.Equal(t, []string{"web"}, alerted)
+
+	require.NoError(t, plum.RemoveMaintenanceWindow("upgrade"))
+	assert.Nil(t, plum.InMaintenance("db-primary"))
+}
+
+func TestMaintenanceWindow_AdHocWindowsSurviveReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goplum.conf")
+	writeConfig(t, path, `
+alert debug.sysout "debug" {}
+check debug.random "db-primary" { percent_good = 1.0 }
+maintenance "nightly" {
+  checks = ["db-*"]
+  schedule = "0 2 * * *"
+  duration = 1h
+}
+`)
+
+	plum := goplum.NewPlum()
+	plum.RegisterPlugins(plugins)
+	require.NoError(t, plum.ReadConfig(path))
+
+	require.NoError(t, plum.AddMaintenanceWindow(&goplum.MaintenanceWindow{
+		Name:     "upgrade",
+		Checks:   []string{"db-*"},
+		Start:    time.Now().Format(time.RFC3339),
+		Duration: time.Hour,
+	}))
+	assert.Error(t, plum.AddMaintenanceWindow(&goplum.MaintenanceWindow{
+		Name:     "nightly",
+		Checks:   []string{"*"},
+		Start:    time.Now().Format(time.RFC3339),
+		Duration: time.Hour,
+	}))
+	assert.Error(t, plum.RemoveMaintenanceWindow("nightly"))
+
+	writeConfig(t, path, `
+alert debug.sysout "debug" {}
+check debug.random "db-primary" { percent_good = 1.0 }
+`)
+	require.NoError(t, plum.ReloadConfig())
+
+	assert.Len(t, plum.Maintenance, 1)
+	assert.Contains(t, plum.Maintenance, "upgrade")
+}
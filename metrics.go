@@ -0,0 +1,208 @@
+package goplum
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var metricsPort = flag.Int("metrics-port", 0, "Port to serve Prometheus metrics on; metrics are disabled if 0")
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+var metricStates = []CheckState{StateIndeterminate, StateGood, StateWarning, StateFailing}
+
+// MetricsServer exposes the state of checks and alerts over HTTP in the Prometheus text format.
+type MetricsServer struct {
+	plum   *Plum
+	server *http.Server
+
+	mu         sync.Mutex
+	executions map[string]map[CheckState]uint64
+	alerts     map[string]map[bool]uint64
+}
+
+func NewMetricsServer(plum *Plum) *MetricsServer {
+	m := &MetricsServer{
+		plum:       plum,
+		executions: make(map[string]map[CheckState]uint64),
+		alerts:     make(map[string]map[bool]uint64),
+	}
+
+	plum.AddCheckListener(m.recordResult)
+	plum.AddAlertListener(m.recordAlert)
+
+	return m
+}
+
+func (m *MetricsServer) Start() {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", *metricsPort))
+	if err != nil {
+		log.Fatalf("Unable to listen on port %d for metrics requests: %v", *metricsPort, err)
+	}
+
+	log.Printf("Starting metrics server on port %d", *metricsPort)
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m)
+	m.server = &http.Server{Handler: mux}
+	if err := m.server.Serve(lis); err != nil && err != http.ErrServerClosed {
+		log.Printf("Error serving metrics: %v", err)
+	}
+}
+
+func (m *MetricsServer) Stop() {
+	if m.server != nil {
+		_ = m.server.Close()
+	}
+}
+
+func (m *MetricsServer) recordResult(check *ScheduledCheck, result Result) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.executions[check.Name] == nil {
+		m.executions[check.Name] = make(map[CheckState]uint64)
+	}
+	m.executions[check.Name][result.State]++
+}
+
+func (m *MetricsServer) recordAlert(name string, _ AlertDetails, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.alerts[name] == nil {
+		m.alerts[name] = make(map[bool]uint64)
+	}
+	m.alerts[name][err == nil]++
+}
+
+func (m *MetricsServer) ServeHTTP(writer http.ResponseWriter, _ *http.Request) {
+	writer.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.write(writer)
+}
+
+// write outputs all metrics in the Prometheus text exposition format. The read lock is held throughout, as the
+// state of each check may be updated by the scheduler at any time.
+func (m *MetricsServer) write(w io.Writer) {
+	m.plum.mu.RLock()
+	defer m.plum.mu.RUnlock()
+
+	checks := make([]*ScheduledCheck, 0, len(m.plum.Checks))
+	for i := range m.plum.Checks {
+		checks = append(checks, m.plum.Checks[i])
+	}
+
+	slices.SortFunc(checks, func(a, b *ScheduledCheck) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	header(w, "goplum_check_state", "gauge", "Whether the check is currently in the given state.")
+	for _, c := range checks {
+		for _, state := range metricStates {
+			value := 0
+			if c.State == state {
+				value = 1
+			}
+			sample(w, "goplum_check_state", checkLabels(c, "state", state.String()), value)
+		}
+	}
+
+	header(w, "goplum_check_suspended", "gauge", "Whether the check is currently suspended.")
+	for _, c := range checks {
+		value := 0
+		if c.Suspended {
+			value = 1
+		}
+		sample(w, "goplum_check_suspended", checkLabels(c), value)
+	}
+
+	header(w, "goplum_check_last_run_timestamp_seconds", "gauge", "The time the check was last executed.")
+	for _, c := range checks {
+		if !c.LastRun.IsZero() {
+			sample(w, "goplum_check_last_run_timestamp_seconds", checkLabels(c), float64(c.LastRun.UnixNano())/1e9)
+		}
+	}
+
+	m.mu.Lock()
+	header(w, "goplum_check_executions_total", "counter", "The number of times the check has been executed, by result.")
+	for _, c := range checks {
+		for _, state := range metricStates {
+			sample(w, "goplum_check_executions_total", checkLabels(c, "result", state.String()), m.executions[c.Name][state])
+		}
+	}
+
+	header(w, "goplum_alerts_sent_total", "counter", "The number of alerts that have been sent, by outcome.")
+	for _, name := range slices.Sorted(maps.Keys(m.alerts)) {
+		sample(w, "goplum_alerts_sent_total", labels("alert", name, "outcome", "success"), m.alerts[name][true])
+		sample(w, "goplum_alerts_sent_total", labels("alert", name, "outcome", "failure"), m.alerts[name][false])
+	}
+	m.mu.Unlock()
+
+	header(w, "goplum_check_fact", "gauge", "Numeric facts reported by the check's most recent result. Durations are in seconds.")
+	for _, c := range checks {
+		last := c.LastResult()
+		if last == nil {
+			continue
+		}
+
+		for _, f := range slices.Sorted(maps.Keys(last.Facts)) {
+			if value, ok := factValue(last.Facts[f]); ok {
+				sample(w, "goplum_check_fact", checkLabels(c, "fact", string(f)), value)
+			}
+		}
+	}
+}
+
+// factValue converts a fact to a number suitable for exporting as a metric, if possible.
+func factValue(value any) (float64, bool) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v.Seconds(), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	default:
+		return 0, false
+	}
+}
+
+func header(w io.Writer, name, kind, help string) {
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func sample[T int | uint64 | float64](w io.Writer, name, labels string, value T) {
+	_, _ = fmt.Fprintf(w, "%s{%s} %s\n", name, labels, strconv.FormatFloat(float64(value), 'g', -1, 64))
+}
+
+// checkLabels returns the standard labels describing a check, along with any extra label pairs given.
+func checkLabels(c *ScheduledCheck, extra ...string) string {
+	return labels(append([]string{"check", c.Name, "type", c.Type, "group", strings.Join(c.Config.Groups, ",")}, extra...)...)
+}
+
+// labels formats the given name/value pairs as a Prometheus label set.
+func labels(pairs ...string) string {
+	res := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		res = append(res, fmt.Sprintf("%s=\"%s\"", pairs[i], labelEscaper.Replace(pairs[i+1])))
+	}
+	return strings.Join(res, ",")
+}
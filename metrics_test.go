@@ -0,0 +1,94 @@
+package goplum_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"chameth.com/goplum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsServer_ExportsCheckMetrics(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goplum.conf")
+	writeConfig(t, path, `
+alert debug.sysout "debug" {}
+group "web" {}
+check debug.random "always \"good\"" {
+  percent_good = 1.0
+  groups = ["web"]
+}
+check debug.random "never-run" {}
+`)
+
+	plum := goplum.NewPlum()
+	plum.RegisterPlugins(plugins)
+	require.NoError(t, plum.ReadConfig(path))
+
+	metrics := goplum.NewMetricsServer(plum)
+	check := plum.Checks[`always "good"`]
+	plum.RunCheck(check)
+	plum.RunCheck(check)
+	plum.RaiseAlerts(check, goplum.StateIndeterminate)
+
+	recorder := httptest.NewRecorder()
+	metrics.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body := recorder.Body.String()
+
+	labels := `check="always \"good\"",type="debug.random",group="web"`
+	assert.Contains(t, body, `goplum_check_state{`+labels+`,state="good"} 1`)
+	assert.Contains(t, body, `goplum_check_state{`+labels+`,state="failing"} 0`)
+	assert.Contains(t, body, `goplum_check_state{check="never-run",type="debug.random",group="",state="indeterminate"} 1`)
+	assert.Contains(t, body, `goplum_check_executions_total{`+labels+`,result="good"} 2`)
+	assert.Contains(t, body, `goplum_check_last_run_timestamp_seconds{`+labels+`} `)
+	assert.Contains(t, body, `goplum_check_fact{`+labels+`,fact="chameth.com/goplum#check_time"} `)
+	assert.Contains(t, body, `goplum_alerts_sent_total{alert="debug",outcome="success"} 1`)
+	assert.Contains(t, body, `goplum_alerts_sent_total{alert="debug",outcome="failure"} 0`)
+	assert.Contains(t, body, "# TYPE goplum_check_executions_total counter\n")
+}
+
+func TestMetricsServer_ScrapeWhileChecksRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goplum.conf")
+	writeConfig(t, path, `
+alert debug.sysout "debug" {}
+check debug.random "flaky" { percent_good = 0.5 }
+`)
+
+	plum := goplum.NewPlum()
+	plum.RegisterPlugins(plugins)
+	require.NoError(t, plum.ReadConfig(path))
+
+	metrics := goplum.NewMetricsServer(plum)
+	check := plum.Checks["flaky"]
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		for range 200 {
+			plum.RunCheck(check)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := range 200 {
+			if i%2 == 0 {
+				plum.Suspend("flaky", 0, "", "")
+			} else {
+				plum.Unsuspend("flaky")
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 200 {
+			recorder := httptest.NewRecorder()
+			metrics.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+			_, _ = io.Copy(io.Discard, recorder.Body)
+		}
+	}()
+	wg.Wait()
+}
@@ -38,8 +38,26 @@ const (
 	StateGood
 	// StateFailing indicates a problem with the service.
 	StateFailing
+	// StateWarning indicates the service is operating but something needs attention, e.g. a certificate
+	// is close to expiry.
+	StateWarning
 )
 
+// Severity returns a number indicating how serious the state is, for comparing states. Failing is more severe
+// than warning, which is more severe than good. Indeterminate states are the least severe.
+func (c CheckState) Severity() int {
+	switch c {
+	case StateGood:
+		return 1
+	case StateWarning:
+		return 2
+	case StateFailing:
+		return 3
+	default:
+		return 0
+	}
+}
+
 // String returns an english, lowercase name for the state.
 func (c CheckState) String() string {
 	switch c {
@@ -49,6 +67,8 @@ func (c CheckState) String() string {
 		return "failing"
 	case StateGood:
 		return "good"
+	case StateWarning:
+		return "warning"
 	default:
 		return "unknown"
 	}
@@ -66,6 +86,8 @@ func (c *CheckState) UnmarshalJSON(val []byte) error {
 		*c = StateFailing
 	case "\"good\"":
 		*c = StateGood
+	case "\"warning\"":
+		*c = StateWarning
 	default:
 		return fmt.Errorf("unknown value for CheckState: %s", val)
 	}
@@ -116,6 +138,15 @@ func IndeterminateResult(format string, a ...any) Result {
 	}
 }
 
+// WarningResult creates a new result indicating the service is working but requires attention.
+func WarningResult(format string, a ...any) Result {
+	return Result{
+		State:  StateWarning,
+		Time:   time.Now(),
+		Detail: fmt.Sprintf(format, a...),
+	}
+}
+
 // FailingResult creates a new result indicating the service is in a bad state.
 func FailingResult(format string, a ...any) Result {
 	return Result{
@@ -166,6 +197,13 @@ type LongRunning interface {
 	Timeout() time.Duration
 }
 
+// Registrar is implemented by plugins that need to keep track of their checks, e.g. to route incoming requests to
+// them. RegisterChecks is called with all of the plugin's checks each time the config is loaded or reloaded, and
+// replaces any checks that were registered previously.
+type Registrar interface {
+	RegisterChecks(checks []Check)
+}
+
 // Stateful is implemented by checks that keep local state that should be persisted across restarts.
 type Stateful interface {
 	Save() any
@@ -0,0 +1,380 @@
+package dns
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"chameth.com/goplum"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+var (
+	// Answers is a comma-separated list of the answers received. Its value is a string.
+	Answers goplum.Fact = "chameth.com/goplum/plugins/dns#answers"
+
+	// AnswerCount is the number of answers received. Its value is an int.
+	AnswerCount goplum.Fact = "chameth.com/goplum/plugins/dns#answer_count"
+)
+
+// recordTypes are the types of record that can be queried.
+var recordTypes = map[string]dnsmessage.Type{
+	"A":     dnsmessage.TypeA,
+	"AAAA":  dnsmessage.TypeAAAA,
+	"CNAME": dnsmessage.TypeCNAME,
+	"MX":    dnsmessage.TypeMX,
+	"NS":    dnsmessage.TypeNS,
+	"PTR":   dnsmessage.TypePTR,
+	"SRV":   dnsmessage.TypeSRV,
+	"TXT":   dnsmessage.TypeTXT,
+}
+
+// resolvConf is the file that the system's nameservers are read from.
+const resolvConf = "/etc/resolv.conf"
+
+type Plugin struct{}
+
+func (p Plugin) Alert(_ string) goplum.Alert {
+	return nil
+}
+
+func (p Plugin) Check(kind string) goplum.Check {
+	switch kind {
+	case "resolve":
+		return ResolveCheck{
+			Type:       "A",
+			MinAnswers: 1,
+		}
+	default:
+		return nil
+	}
+}
+
+type ResolveCheck struct {
+	Name       string
+	Type       string
+	Nameserver string
+	Expected   []string
+	MinAnswers int           `config:"min_answers"`
+	MinTtl     time.Duration `config:"min_ttl"`
+	MaxTtl     time.Duration `config:"max_ttl"`
+}
+
+// answer is a single record received in response to a query.
+type answer struct {
+	value string
+	ttl   time.Duration
+}
+
+func (r ResolveCheck) Execute(ctx context.Context) goplum.Result {
+	nameserver, err := r.nameserver()
+	if err != nil {
+		return goplum.FailingResult("Unable to find system nameserver: %v", err)
+	}
+
+	start := time.Now()
+	answers, err := r.query(ctx, nameserver)
+	elapsed := time.Since(start)
+	if err != nil {
+		return goplum.FailingResult("Unable to resolve %s %s using %s: %v", r.Type, r.Name, nameserver, err)
+	}
+
+	var values []string
+	for _, a := range answers {
+		values = append(values, a.value)
+	}
+
+	result := r.check(answers, values)
+	result.Facts = map[goplum.Fact]any{
+		goplum.ResponseTime: elapsed,
+		Answers:             strings.Join(values, ", "),
+		AnswerCount:         len(answers),
+	}
+	return result
+}
+
+// check verifies the answers received meet the configured expectations.
+func (r ResolveCheck) check(answers []answer, values []string) goplum.Result {
+	if len(answers) < r.MinAnswers {
+		return goplum.FailingResult("Expected at least %d answers, got %d", r.MinAnswers, len(answers))
+	}
+
+	for _, expected := range r.Expected {
+		if !slices.Contains(values, r.normalise(expected)) {
+			return goplum.FailingResult("Expected answer '%s' not found, got [%s]", expected, strings.Join(values, ", "))
+		}
+	}
+
+	for _, a := range answers {
+		if r.MinTtl > 0 && a.ttl < r.MinTtl {
+			return goplum.FailingResult("TTL of '%s' is %s, less than the minimum of %s", a.value, a.ttl, r.MinTtl)
+		}
+
+		if r.MaxTtl > 0 && a.ttl > r.MaxTtl {
+			return goplum.FailingResult("TTL of '%s' is %s, more than the maximum of %s", a.value, a.ttl, r.MaxTtl)
+		}
+	}
+
+	return goplum.GoodResult()
+}
+
+// query sends a query to the nameserver over UDP, retrying over TCP if the response was truncated, and returns
+// the answers of the requested type.
+func (r ResolveCheck) query(ctx context.Context, nameserver string) ([]answer, error) {
+	name, err := dnsmessage.NewName(fqdn(r.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	question := dnsmessage.Question{Name: name, Type: recordTypes[strings.ToUpper(r.Type)], Class: dnsmessage.ClassINET}
+	id := uint16(rand.UintN(1 << 16))
+	query, err := newQuery(id, question)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := exchange(ctx, "udp", nameserver, query, id, question)
+	if err != nil {
+		return nil, err
+	}
+
+	if msg.Truncated {
+		if msg, err = exchange(ctx, "tcp", nameserver, query, id, question); err != nil {
+			return nil, err
+		}
+	}
+
+	if msg.RCode != dnsmessage.RCodeSuccess {
+		return nil, fmt.Errorf("server returned %s", strings.TrimPrefix(msg.RCode.String(), "RCode"))
+	}
+
+	var res []answer
+	for _, rr := range msg.Answers {
+		if rr.Header.Type != question.Type {
+			continue
+		}
+
+		res = append(res, answer{
+			value: format(rr.Body),
+			ttl:   time.Duration(rr.Header.TTL) * time.Second,
+		})
+	}
+	return res, nil
+}
+
+// newQuery builds a recursive query message for the given question.
+func newQuery(id uint16, question dnsmessage.Question) ([]byte, error) {
+	builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: id, RecursionDesired: true})
+	builder.EnableCompression()
+
+	if err := builder.StartQuestions(); err != nil {
+		return nil, err
+	}
+
+	if err := builder.Question(question); err != nil {
+		return nil, err
+	}
+
+	// Advertise a larger UDP payload size using EDNS(0), to avoid falling back to TCP unnecessarily.
+	if err := builder.StartAdditionals(); err != nil {
+		return nil, err
+	}
+
+	var opt dnsmessage.ResourceHeader
+	if err := opt.SetEDNS0(4096, dnsmessage.RCodeSuccess, false); err != nil {
+		return nil, err
+	}
+
+	if err := builder.OPTResource(opt, dnsmessage.OPTResource{}); err != nil {
+		return nil, err
+	}
+
+	return builder.Finish()
+}
+
+// parseResponse unpacks a response, and checks that it answers the query with the given ID and question.
+func parseResponse(response []byte, id uint16, question dnsmessage.Question) (*dnsmessage.Message, error) {
+	msg := &dnsmessage.Message{}
+	if err := msg.Unpack(response); err != nil {
+		return nil, fmt.Errorf("invalid response: %v", err)
+	}
+
+	if msg.ID != id || !msg.Response || len(msg.Questions) != 1 {
+		return nil, fmt.Errorf("invalid response: unexpected message")
+	}
+
+	q := msg.Questions[0]
+	if q.Type != question.Type || q.Class != question.Class || !strings.EqualFold(q.Name.String(), question.Name.String()) {
+		return nil, fmt.Errorf("invalid response: unexpected question")
+	}
+
+	return msg, nil
+}
+
+// exchange sends the query to the nameserver and waits for a response to it. TCP messages are prefixed with their
+// length, as described in RFC 1035. Over UDP, packets that don't answer the query (such as late responses to an
+// earlier query, or spoofed ones) are ignored until the context's deadline passes.
+func exchange(ctx context.Context, network, nameserver string, query []byte, id uint16, question dnsmessage.Question) (*dnsmessage.Message, error) {
+	d := net.Dialer{}
+	conn, err := d.DialContext(ctx, network, nameserver)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if network == "udp" {
+		if _, err := conn.Write(query); err != nil {
+			return nil, err
+		}
+
+		buf := make([]byte, 65535)
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				return nil, err
+			}
+
+			if msg, err := parseResponse(buf[:n], id, question); err == nil {
+				return msg, nil
+			}
+		}
+	}
+
+	if _, err := conn.Write(append(binary.BigEndian.AppendUint16(nil, uint16(len(query))), query...)); err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	var length uint16
+	if err := binary.Read(reader, binary.BigEndian, &length); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(reader, buf); err != nil {
+		return nil, err
+	}
+	return parseResponse(buf, id, question)
+}
+
+// format converts the body of a record to a string for comparison with expected values.
+func format(body dnsmessage.ResourceBody) string {
+	switch b := body.(type) {
+	case *dnsmessage.AResource:
+		return net.IP(b.A[:]).String()
+	case *dnsmessage.AAAAResource:
+		return net.IP(b.AAAA[:]).String()
+	case *dnsmessage.CNAMEResource:
+		return normalise(b.CNAME.String())
+	case *dnsmessage.MXResource:
+		return fmt.Sprintf("%d %s", b.Pref, normalise(b.MX.String()))
+	case *dnsmessage.NSResource:
+		return normalise(b.NS.String())
+	case *dnsmessage.PTRResource:
+		return normalise(b.PTR.String())
+	case *dnsmessage.SRVResource:
+		return fmt.Sprintf("%d %d %d %s", b.Priority, b.Weight, b.Port, normalise(b.Target.String()))
+	case *dnsmessage.TXTResource:
+		return strings.Join(b.TXT, "")
+	default:
+		return body.GoString()
+	}
+}
+
+// normalise converts an expected value to the same form as the answers received, so that they can be compared
+// regardless of how they were written.
+func (r ResolveCheck) normalise(value string) string {
+	if strings.EqualFold(r.Type, "TXT") {
+		return value
+	}
+	return normalise(value)
+}
+
+// normalise lower-cases the hostname at the end of the value and removes its trailing dot. IP addresses are
+// converted to their canonical form.
+func normalise(value string) string {
+	if ip := net.ParseIP(value); ip != nil {
+		return ip.String()
+	}
+
+	fields := strings.Fields(value)
+	if len(fields) > 0 {
+		fields[len(fields)-1] = strings.TrimSuffix(strings.ToLower(fields[len(fields)-1]), ".")
+	}
+	return strings.Join(fields, " ")
+}
+
+// fqdn returns the name with a trailing dot, as required in DNS messages.
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// nameserver returns the address of the nameserver to query. If no port is specified, the standard DNS port is
+// used. If no nameserver is configured, the system's nameserver is used.
+func (r ResolveCheck) nameserver() (string, error) {
+	if len(r.Nameserver) == 0 {
+		return systemNameserver()
+	}
+
+	if _, _, err := net.SplitHostPort(r.Nameserver); err == nil {
+		return r.Nameserver, nil
+	}
+	return net.JoinHostPort(r.Nameserver, "53"), nil
+}
+
+// systemNameserver returns the address of the first nameserver configured in resolv.conf.
+func systemNameserver() (string, error) {
+	f, err := os.Open(resolvConf)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			return net.JoinHostPort(fields[1], "53"), nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no nameservers found in %s", resolvConf)
+}
+
+func (r ResolveCheck) Validate() error {
+	if len(r.Name) == 0 {
+		return fmt.Errorf("missing required argument: name")
+	}
+
+	if _, err := dnsmessage.NewName(fqdn(r.Name)); err != nil {
+		return fmt.Errorf("invalid name: %v", err)
+	}
+
+	if _, ok := recordTypes[strings.ToUpper(r.Type)]; !ok {
+		return fmt.Errorf("unsupported record type: %s", r.Type)
+	}
+
+	if r.MinTtl > 0 && r.MaxTtl > 0 && r.MinTtl > r.MaxTtl {
+		return fmt.Errorf("min_ttl must not be greater than max_ttl")
+	}
+
+	return nil
+}
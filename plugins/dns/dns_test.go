@@ -0,0 +1,278 @@
+package dns
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"chameth.com/goplum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// testRecord is a record served by the test DNS server.
+type testRecord struct {
+	ttl  uint32
+	body dnsmessage.ResourceBody
+}
+
+// testServer is a minimal DNS server that answers queries from a fixed set of records over UDP and TCP. Queries
+// for names starting with "tc." are truncated when made over UDP.
+type testServer struct {
+	records map[string][]testRecord
+	queries chan string
+}
+
+func newTestServer(t *testing.T, records map[string][]testRecord) (*testServer, string) {
+	s := &testServer{records: records, queries: make(chan string, 10)}
+
+	udp, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = udp.Close() })
+
+	tcp, err := net.Listen("tcp", udp.LocalAddr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tcp.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := udp.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			_, _ = udp.WriteTo(s.answer(t, buf[:n], "udp"), addr)
+		}
+	}()
+
+	go func() {
+		for {
+			conn, err := tcp.Accept()
+			if err != nil {
+				return
+			}
+
+			var length uint16
+			_ = binary.Read(conn, binary.BigEndian, &length)
+			query := make([]byte, length)
+			_, _ = io.ReadFull(conn, query)
+			response := s.answer(t, query, "tcp")
+			_, _ = conn.Write(append(binary.BigEndian.AppendUint16(nil, uint16(len(response))), response...))
+			_ = conn.Close()
+		}
+	}()
+
+	return s, udp.LocalAddr().String()
+}
+
+func (s *testServer) answer(t *testing.T, query []byte, network string) []byte {
+	var msg dnsmessage.Message
+	require.NoError(t, msg.Unpack(query))
+
+	question := msg.Questions[0]
+	select {
+	case s.queries <- network:
+	default:
+	}
+
+	key := question.Type.String() + " " + question.Name.String()
+	records, ok := s.records[key]
+
+	header := dnsmessage.Header{ID: msg.ID, Response: true, RecursionDesired: true}
+	if !ok {
+		header.RCode = dnsmessage.RCodeNameError
+	}
+
+	if network == "udp" && strings.HasPrefix(question.Name.String(), "tc.") {
+		header.Truncated = true
+		records = nil
+	}
+
+	response := dnsmessage.Message{Header: header, Questions: msg.Questions}
+	for _, r := range records {
+		response.Answers = append(response.Answers, dnsmessage.Resource{
+			Header: dnsmessage.ResourceHeader{Name: question.Name, Class: dnsmessage.ClassINET, TTL: r.ttl},
+			Body:   r.body,
+		})
+	}
+
+	b, err := response.Pack()
+	require.NoError(t, err)
+	return b
+}
+
+func TestResolveCheck_Execute(t *testing.T) {
+	_, address := newTestServer(t, map[string][]testRecord{
+		"TypeA example.com.": {
+			{300, &dnsmessage.AResource{A: [4]byte{192, 0, 2, 1}}},
+			{300, &dnsmessage.AResource{A: [4]byte{192, 0, 2, 2}}},
+		},
+		"TypeAAAA example.com.": {
+			{60, &dnsmessage.AAAAResource{AAAA: [16]byte{0x20, 0x01, 0x0d, 0xb8, 15: 1}}},
+		},
+		"TypeMX example.com.": {
+			{3600, &dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName("Mail.Example.com.")}},
+		},
+		"TypeTXT example.com.": {
+			{3600, &dnsmessage.TXTResource{TXT: []string{"v=spf1 ", "-all"}}},
+		},
+		"TypeA empty.example.com.": {},
+		"TypeA tc.example.com.": {
+			{300, &dnsmessage.AResource{A: [4]byte{192, 0, 2, 3}}},
+		},
+	})
+
+	tests := []struct {
+		name       string
+		check      ResolveCheck
+		expected   goplum.CheckState
+		detail     string
+		answers    string
+		answerSize int
+	}{
+		{"A", ResolveCheck{Name: "example.com", Expected: []string{"192.0.2.2"}}, goplum.StateGood, "", "192.0.2.1, 192.0.2.2", 2},
+		{"AAAA", ResolveCheck{Name: "example.com", Type: "aaaa", Expected: []string{"2001:0db8::0001"}}, goplum.StateGood, "", "2001:db8::1", 1},
+		{"MX", ResolveCheck{Name: "example.com.", Type: "MX", Expected: []string{"10 mail.example.com."}}, goplum.StateGood, "", "10 mail.example.com", 1},
+		{"TXT", ResolveCheck{Name: "example.com", Type: "TXT", Expected: []string{"v=spf1 -all"}}, goplum.StateGood, "", "v=spf1 -all", 1},
+		{"Truncated", ResolveCheck{Name: "tc.example.com", Expected: []string{"192.0.2.3"}}, goplum.StateGood, "", "192.0.2.3", 1},
+		{"MissingAnswer", ResolveCheck{Name: "example.com", Expected: []string{"192.0.2.3"}}, goplum.StateFailing, "Expected answer '192.0.2.3' not found, got [192.0.2.1, 192.0.2.2]", "192.0.2.1, 192.0.2.2", 2},
+		{"MinAnswers", ResolveCheck{Name: "example.com", MinAnswers: 3}, goplum.StateFailing, "Expected at least 3 answers, got 2", "192.0.2.1, 192.0.2.2", 2},
+		{"NoData", ResolveCheck{Name: "empty.example.com"}, goplum.StateFailing, "Expected at least 1 answers, got 0", "", 0},
+		{"MinTtl", ResolveCheck{Name: "example.com", Type: "AAAA", MinTtl: 5 * time.Minute}, goplum.StateFailing, "TTL of '2001:db8::1' is 1m0s, less than the minimum of 5m0s", "2001:db8::1", 1},
+		{"MaxTtl", ResolveCheck{Name: "example.com", MaxTtl: time.Minute}, goplum.StateFailing, "TTL of '192.0.2.1' is 5m0s, more than the maximum of 1m0s", "192.0.2.1, 192.0.2.2", 2},
+		{"TtlWithinBounds", ResolveCheck{Name: "example.com", MinTtl: time.Minute, MaxTtl: time.Hour}, goplum.StateGood, "", "192.0.2.1, 192.0.2.2", 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check := Plugin{}.Check("resolve").(ResolveCheck)
+			check.Name = test.check.Name
+			check.Nameserver = address
+			check.Expected = test.check.Expected
+			check.MinTtl = test.check.MinTtl
+			check.MaxTtl = test.check.MaxTtl
+			if len(test.check.Type) > 0 {
+				check.Type = test.check.Type
+			}
+			if test.check.MinAnswers > 0 {
+				check.MinAnswers = test.check.MinAnswers
+			}
+			require.NoError(t, check.Validate())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			result := check.Execute(ctx)
+			assert.Equal(t, test.expected, result.State)
+			assert.Equal(t, test.detail, result.Detail)
+			assert.Equal(t, test.answers, result.Facts[Answers])
+			assert.Equal(t, test.answerSize, result.Facts[AnswerCount])
+			assert.IsType(t, time.Duration(0), result.Facts[goplum.ResponseTime])
+		})
+	}
+}
+
+func TestResolveCheck_Errors(t *testing.T) {
+	server, address := newTestServer(t, map[string][]testRecord{})
+
+	check := Plugin{}.Check("resolve").(ResolveCheck)
+	check.Name = "missing.example.com"
+	check.Nameserver = address
+	require.NoError(t, check.Validate())
+
+	result := check.Execute(context.Background())
+	assert.Equal(t, goplum.StateFailing, result.State)
+	assert.Equal(t, "Unable to resolve A missing.example.com using "+address+": server returned NameError", result.Detail)
+	assert.Equal(t, "udp", <-server.queries)
+}
+
+func TestResolveCheck_IgnoresMismatchedResponses(t *testing.T) {
+	udp, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = udp.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		n, addr, err := udp.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+
+		var query dnsmessage.Message
+		if err := query.Unpack(buf[:n]); err != nil {
+			return
+		}
+
+		reply := func(id uint16, question dnsmessage.Question, ip byte) {
+			response := dnsmessage.Message{
+				Header:    dnsmessage.Header{ID: id, Response: true},
+				Questions: []dnsmessage.Question{question},
+				Answers: []dnsmessage.Resource{{
+					Header: dnsmessage.ResourceHeader{Name: question.Name, Class: dnsmessage.ClassINET, TTL: 300},
+					Body:   &dnsmessage.AResource{A: [4]byte{192, 0, 2, ip}},
+				}},
+			}
+			b, _ := response.Pack()
+			_, _ = udp.WriteTo(b, addr)
+		}
+
+		other := query.Questions[0]
+		other.Name = dnsmessage.MustNewName("other.example.com.")
+
+		reply(query.ID+1, query.Questions[0], 1)
+		reply(query.ID, other, 2)
+		reply(query.ID, query.Questions[0], 3)
+	}()
+
+	check := Plugin{}.Check("resolve").(ResolveCheck)
+	check.Name = "example.com"
+	check.Nameserver = udp.LocalAddr().String()
+	check.Expected = []string{"192.0.2.3"}
+	require.NoError(t, check.Validate())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result := check.Execute(ctx)
+	assert.Equal(t, goplum.StateGood, result.State, result.Detail)
+	assert.Equal(t, "192.0.2.3", result.Facts[Answers])
+}
+
+func TestResolveCheck_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		check ResolveCheck
+		error string
+	}{
+		{"MissingName", ResolveCheck{Type: "A"}, "missing required argument: name"},
+		{"InvalidType", ResolveCheck{Name: "example.com", Type: "HINFO"}, "unsupported record type: HINFO"},
+		{"TtlBounds", ResolveCheck{Name: "example.com", Type: "A", MinTtl: time.Hour, MaxTtl: time.Minute}, "min_ttl must not be greater than max_ttl"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.check.Validate()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), test.error)
+		})
+	}
+}
+
+func TestResolveCheck_Nameserver(t *testing.T) {
+	for input, expected := range map[string]string{
+		"192.0.2.53":        "192.0.2.53:53",
+		"192.0.2.53:5353":   "192.0.2.53:5353",
+		"2001:db8::53":      "[2001:db8::53]:53",
+		"[2001:db8::53]:53": "[2001:db8::53]:53",
+		"ns1.example.com":   "ns1.example.com:53",
+	} {
+		actual, err := ResolveCheck{Nameserver: input}.nameserver()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+}
@@ -0,0 +1,95 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"chameth.com/goplum"
+)
+
+// maxAlertOutputSize is the maximum number of bytes of output that will be captured from an alert command.
+const maxAlertOutputSize = 64 * 1024
+
+type CommandAlert struct {
+	Name       string
+	Arguments  []string
+	Env        []string
+	WorkingDir string `config:"working_dir"`
+	Timeout    time.Duration
+}
+
+func (c CommandAlert) Send(details goplum.AlertDetails) error {
+	payload, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	stdout := &limitedBuffer{limit: maxAlertOutputSize}
+	stderr := &limitedBuffer{limit: maxAlertOutputSize}
+
+	cmd := exec.CommandContext(ctx, c.Name, c.Arguments...)
+	cmd.Dir = c.WorkingDir
+	cmd.Env = append(append(os.Environ(), c.Env...), environment(details)...)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = waitDelay
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("command timed out after %s%s", c.Timeout, summarise(stdout, stderr))
+		}
+		return fmt.Errorf("command failed: %v%s", err, summarise(stdout, stderr))
+	}
+
+	return nil
+}
+
+// environment returns the variables describing the alert that are passed to the command.
+func environment(details goplum.AlertDetails) []string {
+	env := []string{
+		"GOPLUM_TEXT=" + details.Text,
+		"GOPLUM_NAME=" + details.Name,
+		"GOPLUM_TYPE=" + details.Type,
+		"GOPLUM_PREVIOUS_STATE=" + details.PreviousState.String(),
+		"GOPLUM_NEW_STATE=" + details.NewState.String(),
+		"GOPLUM_IS_REMINDER=" + strconv.FormatBool(details.IsReminder),
+	}
+
+	if details.LastResult != nil {
+		env = append(env,
+			"GOPLUM_DETAIL="+details.LastResult.Detail,
+			"GOPLUM_TIME="+details.LastResult.Time.Format(time.RFC3339),
+		)
+	}
+
+	return env
+}
+
+func (c CommandAlert) Validate() error {
+	if len(c.Name) == 0 {
+		return fmt.Errorf("missing required argument: name")
+	}
+
+	for _, env := range c.Env {
+		if !strings.Contains(env, "=") {
+			return fmt.Errorf("invalid env %q: expected the format KEY=value", env)
+		}
+	}
+
+	if c.Timeout <= 0 {
+		return fmt.Errorf("invalid timeout: must be greater than zero")
+	}
+
+	return nil
+}
@@ -1,17 +1,35 @@
 package exec
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"chameth.com/goplum"
 )
 
+// waitDelay is how long to wait for a command's output to be closed after it has been killed. Processes started
+// by the command may otherwise keep the output open indefinitely.
+const waitDelay = time.Second
+
+// summaryLength is the maximum number of bytes of each output stream that will be included in a result's detail.
+const summaryLength = 200
+
 type Plugin struct{}
 
 func (p Plugin) Alert(kind string) goplum.Alert {
 	switch kind {
+	case "command":
+		return CommandAlert{
+			Timeout: 20 * time.Second,
+		}
 	default:
 		return nil
 	}
@@ -20,28 +38,194 @@ func (p Plugin) Alert(kind string) goplum.Alert {
 func (p Plugin) Check(kind string) goplum.Check {
 	switch kind {
 	case "command":
-		return CommandCheck{}
+		return CommandCheck{
+			ExitCodes:     []int{0},
+			MaxOutputSize: 64 * 1024,
+		}
 	default:
 		return nil
 	}
 }
 
 type CommandCheck struct {
-	Name      string
-	Arguments []string
+	Name       string
+	Arguments  []string
+	Nagios     bool
+	Env        []string
+	WorkingDir string `config:"working_dir"`
+	Stdin      string
+	User       string
+
+	MaxOutputSize     int    `config:"max_output_size"`
+	ExitCodes         []int  `config:"exit_codes"`
+	ExpectStdout      string `config:"expect_stdout"`
+	ExpectStdoutRegex string `config:"expect_stdout_regex"`
+	ExpectStderr      string `config:"expect_stderr"`
+	ExpectStderrRegex string `config:"expect_stderr_regex"`
 }
 
 func (c CommandCheck) Execute(ctx context.Context) goplum.Result {
+	code, stdout, stderr, err := c.run(ctx)
+	if err != nil {
+		return goplum.FailingResult("command failed: %v%s", err, summarise(stdout, stderr))
+	}
+
+	var result goplum.Result
+	if c.Nagios {
+		result = nagiosResult(code, stdout.String())
+	} else if !slices.Contains(c.ExitCodes, code) {
+		result = goplum.FailingResult("command exited with code %d%s", code, summarise(stdout, stderr))
+	} else {
+		result = goplum.GoodResult()
+	}
+
+	if result.State == goplum.StateGood || result.State == goplum.StateWarning {
+		if err := c.checkOutput(stdout.buf, stderr.buf); err != nil {
+			failure := goplum.FailingResult("%v%s", err, summarise(stdout, stderr))
+			failure.Facts = result.Facts
+			return failure
+		}
+	}
+
+	return result
+}
+
+// run executes the command, and returns its exit code and output. An error is returned if the command couldn't be
+// started, or if it was killed by a signal (e.g. because it timed out).
+func (c CommandCheck) run(ctx context.Context) (int, *limitedBuffer, *limitedBuffer, error) {
+	stdout := &limitedBuffer{limit: c.MaxOutputSize}
+	stderr := &limitedBuffer{limit: c.MaxOutputSize}
+
 	cmd := exec.CommandContext(ctx, c.Name, c.Arguments...)
-	if err := cmd.Run(); err != nil {
-		return goplum.FailingResult("command failed: %v", err)
+	cmd.Dir = c.WorkingDir
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = waitDelay
+
+	if len(c.Env) > 0 {
+		cmd.Env = append(os.Environ(), c.Env...)
 	}
-	return goplum.GoodResult()
+
+	if len(c.Stdin) > 0 {
+		cmd.Stdin = strings.NewReader(c.Stdin)
+	}
+
+	if len(c.User) > 0 {
+		attr, err := runAs(c.User)
+		if err != nil {
+			return 0, stdout, stderr, err
+		}
+		cmd.SysProcAttr = attr
+	}
+
+	var exitErr *exec.ExitError
+	if err := cmd.Run(); errors.As(err, &exitErr) && exitErr.ExitCode() >= 0 {
+		return exitErr.ExitCode(), stdout, stderr, nil
+	} else if err != nil {
+		return 0, stdout, stderr, err
+	}
+	return 0, stdout, stderr, nil
+}
+
+// checkOutput verifies that the output of the command meets the configured expectations.
+func (c CommandCheck) checkOutput(stdout, stderr []byte) error {
+	if len(c.ExpectStdout) > 0 && !bytes.Contains(stdout, []byte(c.ExpectStdout)) {
+		return fmt.Errorf("stdout did not contain '%s'", c.ExpectStdout)
+	}
+
+	if len(c.ExpectStdoutRegex) > 0 && !regexp.MustCompile(c.ExpectStdoutRegex).Match(stdout) {
+		return fmt.Errorf("stdout did not match '%s'", c.ExpectStdoutRegex)
+	}
+
+	if len(c.ExpectStderr) > 0 && !bytes.Contains(stderr, []byte(c.ExpectStderr)) {
+		return fmt.Errorf("stderr did not contain '%s'", c.ExpectStderr)
+	}
+
+	if len(c.ExpectStderrRegex) > 0 && !regexp.MustCompile(c.ExpectStderrRegex).Match(stderr) {
+		return fmt.Errorf("stderr did not match '%s'", c.ExpectStderrRegex)
+	}
+
+	return nil
+}
+
+// summarise formats the start of the command's output for use in a result's detail.
+func summarise(stdout, stderr *limitedBuffer) string {
+	var res strings.Builder
+	for _, stream := range []struct {
+		name   string
+		buffer *limitedBuffer
+	}{{"stdout", stdout}, {"stderr", stderr}} {
+		output := bytes.TrimSpace(stream.buffer.buf)
+		if len(output) == 0 {
+			continue
+		}
+
+		if len(output) > summaryLength || stream.buffer.truncated {
+			_, _ = fmt.Fprintf(&res, " (%s: %q...)", stream.name, output[:min(len(output), summaryLength)])
+		} else {
+			_, _ = fmt.Fprintf(&res, " (%s: %q)", stream.name, output)
+		}
+	}
+	return res.String()
 }
 
 func (c CommandCheck) Validate() error {
 	if len(c.Name) == 0 {
 		return fmt.Errorf("missing required argument: name")
 	}
+
+	for _, env := range c.Env {
+		if !strings.Contains(env, "=") {
+			return fmt.Errorf("invalid env %q: expected the format KEY=value", env)
+		}
+	}
+
+	if len(c.User) > 0 {
+		if _, err := runAs(c.User); err != nil {
+			return fmt.Errorf("invalid user: %v", err)
+		}
+	}
+
+	if c.MaxOutputSize <= 0 {
+		return fmt.Errorf("invalid max_output_size: must be greater than zero")
+	}
+
+	if c.Nagios && !slices.Equal(c.ExitCodes, []int{0}) {
+		return fmt.Errorf("exit_codes cannot be used with nagios")
+	}
+
+	if len(c.ExitCodes) == 0 {
+		return fmt.Errorf("invalid exit_codes: at least one exit code must be given")
+	}
+
+	for _, pattern := range []struct {
+		name  string
+		value string
+	}{{"expect_stdout_regex", c.ExpectStdoutRegex}, {"expect_stderr_regex", c.ExpectStderrRegex}} {
+		if _, err := regexp.Compile(pattern.value); err != nil {
+			return fmt.Errorf("invalid %s: %v", pattern.name, err)
+		}
+	}
+
 	return nil
 }
+
+// limitedBuffer is an io.Writer that keeps the first `limit` bytes written to it, and silently discards the rest.
+type limitedBuffer struct {
+	buf       []byte
+	limit     int
+	truncated bool
+}
+
+func (l *limitedBuffer) Write(p []byte) (int, error) {
+	remaining := l.limit - len(l.buf)
+	if remaining < len(p) {
+		l.truncated = true
+	}
+	l.buf = append(l.buf, p[:min(remaining, len(p))]...)
+	return len(p), nil
+}
+
+func (l *limitedBuffer) String() string {
+	return string(l.buf)
+}
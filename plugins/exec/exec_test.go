@@ -0,0 +1,316 @@
+package exec
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"chameth.com/goplum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// shell returns a check that runs the given script using /bin/sh.
+func shell(script string) CommandCheck {
+	check := Plugin{}.Check("command").(CommandCheck)
+	check.Name = "/bin/sh"
+	check.Arguments = []string{"-c", script}
+	return check
+}
+
+func TestCommandCheck_Execute(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		check    CommandCheck
+		expected goplum.CheckState
+		detail   string
+	}{
+		{"Success", shell("exit 0"), goplum.StateGood, ""},
+		{"Failure", shell("exit 1"), goplum.StateFailing, "command exited with code 1"},
+		{"FailureWithOutput", shell("echo working; echo 'disk full' >&2; exit 1"), goplum.StateFailing, `command exited with code 1 (stdout: "working") (stderr: "disk full")`},
+		{"MissingCommand", CommandCheck{Name: "/does/not/exist", ExitCodes: []int{0}, MaxOutputSize: 1024}, goplum.StateFailing, "command failed: fork/exec /does/not/exist: no such file or directory"},
+		{
+			name:     "ExpectedExitCode",
+			check:    func() CommandCheck { c := shell("exit 3"); c.ExitCodes = []int{0, 3}; return c }(),
+			expected: goplum.StateGood,
+		},
+		{
+			name: "Env",
+			check: func() CommandCheck {
+				c := shell(`test "$GREETING" = "hello"`)
+				c.Env = []string{"GREETING=hello"}
+				return c
+			}(),
+			expected: goplum.StateGood,
+		},
+		{
+			name:     "WorkingDir",
+			check:    func() CommandCheck { c := shell("pwd"); c.WorkingDir = dir; c.ExpectStdout = dir; return c }(),
+			expected: goplum.StateGood,
+		},
+		{
+			name:     "Stdin",
+			check:    func() CommandCheck { c := shell("cat"); c.Stdin = "ping"; c.ExpectStdout = "ping"; return c }(),
+			expected: goplum.StateGood,
+		},
+		{
+			name:     "StdoutMismatch",
+			check:    func() CommandCheck { c := shell("echo status: degraded"); c.ExpectStdout = "status: ok"; return c }(),
+			expected: goplum.StateFailing,
+			detail:   `stdout did not contain 'status: ok' (stdout: "status: degraded")`,
+		},
+		{
+			name:     "StdoutRegex",
+			check:    func() CommandCheck { c := shell("echo 42 items"); c.ExpectStdoutRegex = `^\d+ items`; return c }(),
+			expected: goplum.StateGood,
+		},
+		{
+			name:     "StderrMismatch",
+			check:    func() CommandCheck { c := shell("echo warning >&2"); c.ExpectStderrRegex = "^$"; return c }(),
+			expected: goplum.StateFailing,
+			detail:   `stderr did not match '^$' (stderr: "warning")`,
+		},
+		{
+			name:     "TruncatedOutput",
+			check:    func() CommandCheck { c := shell("echo 0123456789; exit 1"); c.MaxOutputSize = 5; return c }(),
+			expected: goplum.StateFailing,
+			detail:   `command exited with code 1 (stdout: "01234"...)`,
+		},
+		{
+			name:     "LongOutput",
+			check:    shell("printf '%0300d' 0; exit 1"),
+			expected: goplum.StateFailing,
+			detail:   `command exited with code 1 (stdout: "` + strings.Repeat("0", summaryLength) + `"...)`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.NoError(t, test.check.Validate())
+
+			result := test.check.Execute(context.Background())
+			assert.Equal(t, test.expected, result.State)
+			assert.Equal(t, test.detail, result.Detail)
+		})
+	}
+}
+
+func TestCommandCheck_User(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skip("running commands as another user requires root")
+	}
+
+	nobody, err := user.Lookup("nobody")
+	if err != nil {
+		t.Skipf("unable to find nobody user: %v", err)
+	}
+
+	check := shell("id -u")
+	check.User = "nobody"
+	check.ExpectStdout = nobody.Uid
+	require.NoError(t, check.Validate())
+
+	result := check.Execute(context.Background())
+	assert.Equal(t, goplum.StateGood, result.State, result.Detail)
+}
+
+func TestCommandCheck_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		check func(c *CommandCheck)
+		error string
+	}{
+		{"MissingName", func(c *CommandCheck) { c.Name = "" }, "missing required argument: name"},
+		{"InvalidEnv", func(c *CommandCheck) { c.Env = []string{"GREETING"} }, "invalid env"},
+		{"UnknownUser", func(c *CommandCheck) { c.User = "goplum-does-not-exist" }, "invalid user"},
+		{"InvalidMaxOutputSize", func(c *CommandCheck) { c.MaxOutputSize = 0 }, "invalid max_output_size"},
+		{"NoExitCodes", func(c *CommandCheck) { c.ExitCodes = nil }, "invalid exit_codes"},
+		{"NagiosExitCodes", func(c *CommandCheck) { c.Nagios = true; c.ExitCodes = []int{0, 1} }, "exit_codes cannot be used with nagios"},
+		{"InvalidStdoutRegex", func(c *CommandCheck) { c.ExpectStdoutRegex = "(" }, "invalid expect_stdout_regex"},
+		{"InvalidStderrRegex", func(c *CommandCheck) { c.ExpectStderrRegex = "(" }, "invalid expect_stderr_regex"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check := shell("true")
+			test.check(&check)
+
+			err := check.Validate()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), test.error)
+		})
+	}
+}
+
+func TestCommandCheck_Nagios(t *testing.T) {
+	tests := []struct {
+		name     string
+		script   string
+		expected goplum.CheckState
+		detail   string
+	}{
+		{"Ok", "echo 'DISK OK - free space: 40%'; exit 0", goplum.StateGood, "DISK OK - free space: 40%"},
+		{"Warning", "echo 'DISK WARNING - free space: 15%'; exit 1", goplum.StateWarning, "DISK WARNING - free space: 15%"},
+		{"Critical", "echo 'DISK CRITICAL - free space: 2%'; exit 2", goplum.StateFailing, "DISK CRITICAL - free space: 2%"},
+		{"Unknown", "echo 'DISK UNKNOWN - no such mount'; exit 3", goplum.StateIndeterminate, "DISK UNKNOWN - no such mount"},
+		{"OtherExitCode", "echo 'Something broke'; exit 127", goplum.StateIndeterminate, "Something broke"},
+		{"NoOutput", "exit 2", goplum.StateFailing, "command exited with code 2"},
+		{"KilledBySignal", "kill -9 $$", goplum.StateFailing, "command failed: signal: killed"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check := shell(test.script)
+			check.Nagios = true
+			require.NoError(t, check.Validate())
+
+			result := check.Execute(context.Background())
+			assert.Equal(t, test.expected, result.State)
+			assert.Equal(t, test.detail, result.Detail)
+		})
+	}
+}
+
+func TestParseNagiosOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		text   string
+		facts  map[goplum.Fact]any
+	}{
+		{
+			name:   "TextOnly",
+			output: "PING OK - Packet loss = 0%\n",
+			text:   "PING OK - Packet loss = 0%",
+			facts:  map[goplum.Fact]any{},
+		},
+		{
+			name:   "Perfdata",
+			output: "PING OK - Packet loss = 0%, RTA = 0.80 ms|rta=0.800000ms;100.000000;500.000000;0.000000 pl=0%;20;60;0\n",
+			text:   "PING OK - Packet loss = 0%, RTA = 0.80 ms",
+			facts: map[goplum.Fact]any{
+				perfdataFact("rta"):      0.8,
+				perfdataFact("rta:warn"): "100.000000",
+				perfdataFact("rta:crit"): "500.000000",
+				perfdataFact("pl"):       0.0,
+				perfdataFact("pl:warn"):  "20",
+				perfdataFact("pl:crit"):  "60",
+			},
+		},
+		{
+			name:   "QuotedLabels",
+			output: "DISK OK | '/ free'=1024MB;;;0;2048 'it''s'=5c",
+			text:   "DISK OK",
+			facts: map[goplum.Fact]any{
+				perfdataFact("/ free"): 1024.0,
+				perfdataFact("it's"):   5.0,
+			},
+		},
+		{
+			name:   "LongTextPerfdata",
+			output: "DISK OK | /=2643MB;5948;5958;0;5968\n/ 15272 MB (77%);\n/boot 68 MB (69%);\n| /boot=68MB;88;93;0;98\n/home=69357MB;253404;253409;0;253414\n",
+			text:   "DISK OK",
+			facts: map[goplum.Fact]any{
+				perfdataFact("/"):          2643.0,
+				perfdataFact("/:warn"):     "5948",
+				perfdataFact("/:crit"):     "5958",
+				perfdataFact("/boot"):      68.0,
+				perfdataFact("/boot:warn"): "88",
+				perfdataFact("/boot:crit"): "93",
+				perfdataFact("/home"):      69357.0,
+				perfdataFact("/home:warn"): "253404",
+				perfdataFact("/home:crit"): "253409",
+			},
+		},
+		{
+			name:   "InvalidMetrics",
+			output: "OK | novalue=U; =5 garbage valid=@10:20",
+			text:   "OK",
+			facts:  map[goplum.Fact]any{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			text, facts := parseNagiosOutput(test.output)
+			assert.Equal(t, test.text, text)
+			assert.Equal(t, test.facts, facts)
+		})
+	}
+}
+
+func TestCommandAlert_Send(t *testing.T) {
+	dir := t.TempDir()
+
+	alert := Plugin{}.Alert("command").(CommandAlert)
+	alert.Name = "/bin/sh"
+	alert.Arguments = []string{"-c", `cat > payload.json; env | grep ^GOPLUM_ | sort > env.txt`}
+	alert.WorkingDir = dir
+	require.NoError(t, alert.Validate())
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	details := goplum.AlertDetails{
+		Text:          "Check 'web' is now failing (timed out), was good.",
+		Name:          "web",
+		Type:          "http.get",
+		LastResult:    &goplum.Result{State: goplum.StateFailing, Time: now, Detail: "timed out"},
+		PreviousState: goplum.StateGood,
+		NewState:      goplum.StateFailing,
+	}
+	require.NoError(t, alert.Send(details))
+
+	payload, err := os.ReadFile(filepath.Join(dir, "payload.json"))
+	require.NoError(t, err)
+
+	var received goplum.AlertDetails
+	require.NoError(t, json.Unmarshal(payload, &received))
+	assert.Equal(t, details.Text, received.Text)
+	assert.Equal(t, details.NewState, received.NewState)
+	assert.Equal(t, "timed out", received.LastResult.Detail)
+
+	env, err := os.ReadFile(filepath.Join(dir, "env.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"GOPLUM_DETAIL=timed out",
+		"GOPLUM_IS_REMINDER=false",
+		"GOPLUM_NAME=web",
+		"GOPLUM_NEW_STATE=failing",
+		"GOPLUM_PREVIOUS_STATE=good",
+		"GOPLUM_TEXT=Check 'web' is now failing (timed out), was good.",
+		"GOPLUM_TIME=2024-01-02T03:04:05Z",
+		"GOPLUM_TYPE=http.get",
+	}, "\n")+"\n", string(env))
+}
+
+func TestCommandAlert_SendErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		timeout time.Duration
+		error   string
+	}{
+		{"NonZeroExit", "echo 'rate limited' >&2; exit 4", time.Second, `command failed: exit status 4 (stderr: "rate limited")`},
+		{"Timeout", "sleep 5", 50 * time.Millisecond, "command timed out after 50ms"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			alert := Plugin{}.Alert("command").(CommandAlert)
+			alert.Name = "/bin/sh"
+			alert.Arguments = []string{"-c", test.script}
+			alert.Timeout = test.timeout
+			require.NoError(t, alert.Validate())
+
+			err := alert.Send(goplum.AlertDetails{LastResult: &goplum.Result{}})
+			require.Error(t, err)
+			assert.Equal(t, test.error, err.Error())
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package exec
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"chameth.com/goplum"
+)
+
+// Exit codes used by Nagios plugins to report the state of a service.
+const (
+	nagiosOk       = 0
+	nagiosWarning  = 1
+	nagiosCritical = 2
+)
+
+// nagiosResult determines the result of a Nagios plugin from its exit code and output. The first line of output is
+// used as the detail of the result, and any performance data is added as facts.
+func nagiosResult(code int, output string) goplum.Result {
+	text, perfdata := parseNagiosOutput(output)
+	if len(text) == 0 && code != nagiosOk {
+		text = fmt.Sprintf("command exited with code %d", code)
+	}
+
+	var result goplum.Result
+	switch code {
+	case nagiosOk:
+		result = goplum.GoodResult()
+		result.Detail = text
+	case nagiosWarning:
+		result = goplum.WarningResult("%s", text)
+	case nagiosCritical:
+		result = goplum.FailingResult("%s", text)
+	default:
+		result = goplum.IndeterminateResult("%s", text)
+	}
+
+	if len(perfdata) > 0 {
+		result.Facts = perfdata
+	}
+	return result
+}
+
+// parseNagiosOutput splits the output of a Nagios plugin into the text of its first line and facts for any
+// performance data. Performance data follows a `|` on the first line, and on any line after the first `|` in
+// the long text that follows it.
+func parseNagiosOutput(output string) (string, map[goplum.Fact]any) {
+	first, rest, _ := strings.Cut(strings.TrimRight(output, "\r\n"), "\n")
+	text, perfdata, _ := strings.Cut(first, "|")
+
+	if _, extra, found := strings.Cut(rest, "|"); found {
+		perfdata += " " + strings.ReplaceAll(extra, "\n", " ")
+	}
+
+	return strings.TrimSpace(text), parsePerfdata(perfdata)
+}
+
+// parsePerfdata parses performance data in the format `'label'=value[UOM];[warn];[crit];[min];[max]`. The value
+// of each metric is added as a fact, along with the warning and critical thresholds if they are present. Metrics
+// that can't be parsed are ignored.
+func parsePerfdata(perfdata string) map[goplum.Fact]any {
+	facts := make(map[goplum.Fact]any)
+	for _, metric := range splitPerfdata(perfdata) {
+		label, data, found := strings.Cut(metric, "=")
+		if !found {
+			continue
+		}
+
+		label = strings.ReplaceAll(strings.Trim(label, "'"), "''", "'")
+		fields := strings.Split(data, ";")
+		value, err := strconv.ParseFloat(strings.TrimRightFunc(fields[0], isUnit), 64)
+		if len(label) == 0 || err != nil {
+			continue
+		}
+
+		facts[perfdataFact(label)] = value
+		if len(fields) > 1 && len(fields[1]) > 0 {
+			facts[perfdataFact(label+":warn")] = fields[1]
+		}
+		if len(fields) > 2 && len(fields[2]) > 0 {
+			facts[perfdataFact(label+":crit")] = fields[2]
+		}
+	}
+	return facts
+}
+
+// splitPerfdata splits performance data into individual metrics. Metrics are separated by whitespace, except
+// within quoted labels.
+func splitPerfdata(perfdata string) []string {
+	var res []string
+	var current strings.Builder
+	quoted := false
+	for _, r := range perfdata {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+			current.WriteRune(r)
+		case !quoted && (r == ' ' || r == '\t' || r == '\n' || r == '\r'):
+			if current.Len() > 0 {
+				res = append(res, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	if current.Len() > 0 {
+		res = append(res, current.String())
+	}
+	return res
+}
+
+// isUnit determines whether the rune may be part of a unit of measurement, such as `ms`, `%` or `KB`.
+func isUnit(r rune) bool {
+	return unicode.IsLetter(r) || r == '%'
+}
+
+func perfdataFact(label string) goplum.Fact {
+	return goplum.Fact("chameth.com/goplum/plugins/exec#perfdata:" + label)
+}
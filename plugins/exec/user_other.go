@@ -0,0 +1,13 @@
+//go:build !unix
+
+package exec
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// runAs returns an error, as running commands as another user is only supported on unix systems.
+func runAs(_ string) (*syscall.SysProcAttr, error) {
+	return nil, fmt.Errorf("running commands as another user is not supported on this platform")
+}
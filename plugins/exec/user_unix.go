@@ -0,0 +1,35 @@
+//go:build unix
+
+package exec
+
+import (
+	"os/user"
+	"strconv"
+	"syscall"
+)
+
+// runAs returns the attributes needed to run a process as the given user, which may be a username or numeric ID.
+// The process runs with the user's primary group.
+func runAs(name string) (*syscall.SysProcAttr, error) {
+	u, err := user.Lookup(name)
+	if err != nil {
+		var idErr error
+		if u, idErr = user.LookupId(name); idErr != nil {
+			return nil, err
+		}
+	}
+
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	gid, err := strconv.ParseUint(u.Gid, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	return &syscall.SysProcAttr{
+		Credential: &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid)},
+	}, nil
+}
@@ -2,22 +2,42 @@ package heartbeat
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"chameth.com/goplum"
+	"chameth.com/goplum/internal"
 )
 
-var checks = make(map[string]*ReceivedCheck)
+// Duration is the time taken by the most recent run of the job, from the start heartbeat to the heartbeat
+// indicating it succeeded or failed. Its value is a time.Duration.
+var Duration goplum.Fact = "chameth.com/goplum/plugins/heartbeat#duration"
+
+// maxBodySize is the maximum number of bytes that will be read from the body of a heartbeat.
+const maxBodySize = 1024
+
+// Actions that may be appended to a heartbeat's path.
+const (
+	actionSuccess = ""
+	actionStart   = "start"
+	actionFail    = "fail"
+)
 
 type Plugin struct {
 	Port int
 	Path string
+
+	mu     sync.RWMutex
+	checks map[string]*ReceivedCheck
 }
 
 func (p *Plugin) Alert(_ string) goplum.Alert {
@@ -28,6 +48,7 @@ func (p *Plugin) Check(kind string) goplum.Check {
 	switch kind {
 	case "received":
 		return &ReceivedCheck{
+			Grace:   time.Hour,
 			created: time.Now(),
 		}
 	default:
@@ -52,44 +73,178 @@ func (p *Plugin) Validate() error {
 	return nil
 }
 
+// RegisterChecks replaces the checks that heartbeats are routed to, so that checks removed from the config stop
+// receiving them.
+func (p *Plugin) RegisterChecks(checks []goplum.Check) {
+	registered := make(map[string]*ReceivedCheck, len(checks))
+	for i := range checks {
+		if check, ok := checks[i].(*ReceivedCheck); ok {
+			registered[check.ID] = check
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.checks = registered
+}
+
 func (p *Plugin) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 	if !strings.HasPrefix(request.URL.Path, p.Path) {
 		writer.WriteHeader(http.StatusNotFound)
 		return
 	}
 
-	id := strings.ToLower(strings.TrimPrefix(request.URL.Path, p.Path))
-	if check, ok := checks[id]; ok {
-		log.Printf("Received heartbeat with ID %s", id)
-		check.received = time.Now()
-		writer.WriteHeader(http.StatusAccepted)
+	id, action, _ := strings.Cut(strings.ToLower(strings.TrimPrefix(request.URL.Path, p.Path)), "/")
+	p.mu.RLock()
+	check, ok := p.checks[id]
+	p.mu.RUnlock()
+	if !ok || (action != actionSuccess && action != actionStart && action != actionFail) {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if !check.authorised(request) {
+		log.Printf("Rejected heartbeat with ID %s: invalid token", id)
+		writer.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(request.Body, maxBodySize))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	writer.WriteHeader(http.StatusNotFound)
+	log.Printf("Received heartbeat with ID %s (action: %q)", id, action)
+	check.record(action, strings.TrimSpace(string(body)), time.Now())
+	writer.WriteHeader(http.StatusAccepted)
 }
 
 type ReceivedCheck struct {
-	ID     string `config:"id"`
-	Within time.Duration
+	ID          string `config:"id"`
+	Within      time.Duration
+	Schedule    string
+	Grace       time.Duration
+	MaxDuration time.Duration `config:"max_duration"`
+	Token       string
 
+	mu       sync.Mutex
+	schedule *internal.Schedule
 	created  time.Time
+	started  time.Time
 	received time.Time
+	failed   time.Time
+	detail   string
+	duration time.Duration
+}
+
+// authorised determines whether the request includes the check's token, if it has one. The token may be given
+// as a bearer token in the Authorization header, or in the token query parameter.
+func (g *ReceivedCheck) authorised(request *http.Request) bool {
+	if g.Token == "" {
+		return true
+	}
+
+	token, ok := strings.CutPrefix(request.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		token = request.URL.Query().Get("token")
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token), []byte(g.Token)) == 1
+}
+
+// record updates the state of the check after a heartbeat is received. Heartbeats may include an exit code or a
+// message in their body. Successful heartbeats with a non-zero exit code are treated as failures.
+func (g *ReceivedCheck) record(action, body string, now time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if action == actionStart {
+		g.started = now
+		return
+	}
+
+	code, err := strconv.Atoi(body)
+	isCode := err == nil
+
+	// Runs that didn't send a start heartbeat have no known duration, so don't report the previous run's.
+	g.duration = 0
+	if g.running() {
+		g.duration = now.Sub(g.started)
+	}
+
+	switch {
+	case action == actionFail && isCode:
+		g.failed = now
+		g.detail = fmt.Sprintf("Job failed with exit code %d", code)
+	case action == actionFail && len(body) > 0:
+		g.failed = now
+		g.detail = fmt.Sprintf("Job failed: %s", body)
+	case action == actionFail:
+		g.failed = now
+		g.detail = "Job failed"
+	case isCode && code != 0:
+		g.failed = now
+		g.detail = fmt.Sprintf("Job failed with exit code %d", code)
+	case isCode:
+		g.received = now
+		g.detail = ""
+	default:
+		g.received = now
+		g.detail = body
+	}
+}
+
+// running determines whether a run of the job has started but not yet finished.
+func (g *ReceivedCheck) running() bool {
+	return g.started.After(g.received) && g.started.After(g.failed)
 }
 
 func (g *ReceivedCheck) Execute(_ context.Context) goplum.Result {
-	if g.received.IsZero() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	result := g.check()
+	if g.duration > 0 {
+		result.Facts = map[goplum.Fact]any{Duration: g.duration}
+	}
+	return result
+}
+
+func (g *ReceivedCheck) check() goplum.Result {
+	if g.failed.After(g.received) {
+		return goplum.FailingResult("%s", g.detail)
+	}
+
+	if g.running() && g.MaxDuration > 0 {
+		if delta := time.Since(g.started); delta > g.MaxDuration {
+			return goplum.FailingResult("Job started %s ago and has not finished", delta.Round(time.Second))
+		}
+	}
+
+	if g.schedule != nil {
+		// The job is overdue if it was due (and the grace period has passed) since we last heard from it, ignoring
+		// any times it was due before we started monitoring.
+		due := g.schedule.Prev(time.Now().Add(-g.Grace))
+		if !due.IsZero() && !due.Before(g.created) && g.received.Before(due) {
+			return goplum.FailingResult("No heartbeat received since job was due at %s", due.Format("2006-01-02 15:04"))
+		}
+	} else if g.received.IsZero() {
 		// We've not received a heartbeat since we started
 		if delta := time.Since(g.created); delta > g.Within {
 			return goplum.FailingResult("No heartbeat received in %s", delta)
 		}
-		return goplum.IndeterminateResult("No heartbeat received since monitoring started at %s", g.created)
+	} else if delta := time.Since(g.received); delta > g.Within {
+		return goplum.FailingResult("No heartbeat received in %s", delta)
 	}
 
-	if delta := time.Since(g.received); delta > g.Within {
-		return goplum.FailingResult("No heartbeat received in %s", delta)
+	if g.received.IsZero() {
+		return goplum.IndeterminateResult("No heartbeat received since monitoring started at %s", g.created)
 	}
-	return goplum.GoodResult()
+
+	result := goplum.GoodResult()
+	result.Detail = g.detail
+	return result
 }
 
 var idRegex = regexp.MustCompile("^[0-9a-f]{32}$")
@@ -100,29 +255,73 @@ func (g *ReceivedCheck) Validate() error {
 		return fmt.Errorf("id must be a 32-character hexadecimal string")
 	}
 
-	if g.Within < 30*time.Second {
+	if len(g.Schedule) > 0 {
+		if g.Within != 0 {
+			return fmt.Errorf("only one of within and schedule may be specified")
+		}
+
+		schedule, err := internal.ParseCron(g.Schedule)
+		if err != nil {
+			return err
+		}
+		g.schedule = schedule
+
+		if g.Grace < 0 {
+			return fmt.Errorf("grace must not be negative")
+		}
+	} else if g.Within < 30*time.Second {
 		return fmt.Errorf("within must be at least 30 seconds")
 	}
 
-	checks[g.ID] = g
+	if g.MaxDuration < 0 {
+		return fmt.Errorf("max_duration must not be negative")
+	}
+
+	if g.MaxDuration == 0 {
+		// A run that takes longer than this will already have missed its heartbeat.
+		if g.schedule != nil {
+			g.MaxDuration = g.Grace
+		} else {
+			g.MaxDuration = g.Within
+		}
+	}
+
 	return nil
 }
 
 type SavedState struct {
 	Created  time.Time
+	Started  time.Time
 	Received time.Time
+	Failed   time.Time
+	Detail   string
+	Duration time.Duration
 }
 
 func (g *ReceivedCheck) Save() any {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	return SavedState{
 		Created:  g.created,
+		Started:  g.started,
 		Received: g.received,
+		Failed:   g.failed,
+		Detail:   g.detail,
+		Duration: g.duration,
 	}
 }
 
 func (g *ReceivedCheck) Restore(restorer func(any)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	state := SavedState{}
 	restorer(&state)
 	g.created = state.Created
+	g.started = state.Started
 	g.received = state.Received
+	g.failed = state.Failed
+	g.detail = state.Detail
+	g.duration = state.Duration
 }
@@ -0,0 +1,271 @@
+package heartbeat
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"chameth.com/goplum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testId = "bf7a7fa97f112bf949e6de4188d6a991"
+
+// heartbeat describes a request sent to the plugin, and how long before the check is executed it was sent.
+type heartbeat struct {
+	action string
+	body   string
+	ago    time.Duration
+}
+
+func TestReceivedCheck_Execute(t *testing.T) {
+	tests := []struct {
+		name       string
+		heartbeats []heartbeat
+		expected   goplum.CheckState
+		detail     string
+		duration   time.Duration
+	}{
+		{"NoHeartbeats", nil, goplum.StateIndeterminate, "No heartbeat received since monitoring started", 0},
+		{"Success", []heartbeat{{"", "", time.Minute}}, goplum.StateGood, "", 0},
+		{"SuccessWithMessage", []heartbeat{{"", "Backed up 12 files", time.Minute}}, goplum.StateGood, "Backed up 12 files", 0},
+		{"SuccessWithExitCode", []heartbeat{{"", "0", time.Minute}}, goplum.StateGood, "", 0},
+		{"NonZeroExitCode", []heartbeat{{"", "3", time.Minute}}, goplum.StateFailing, "Job failed with exit code 3", 0},
+		{"Fail", []heartbeat{{"fail", "", time.Minute}}, goplum.StateFailing, "Job failed", 0},
+		{"FailWithMessage", []heartbeat{{"fail", "Disk full", time.Minute}}, goplum.StateFailing, "Job failed: Disk full", 0},
+		{"FailWithExitCode", []heartbeat{{"fail", "2", time.Minute}}, goplum.StateFailing, "Job failed with exit code 2", 0},
+		{"SuccessAfterFail", []heartbeat{{"fail", "", 2 * time.Minute}, {"", "", time.Minute}}, goplum.StateGood, "", 0},
+		{"FailAfterSuccess", []heartbeat{{"", "", 2 * time.Minute}, {"fail", "", time.Minute}}, goplum.StateFailing, "Job failed", 0},
+		{"StillFailingWhileRunning", []heartbeat{{"fail", "", 2 * time.Minute}, {"start", "", time.Minute}}, goplum.StateFailing, "Job failed", 0},
+		{"Duration", []heartbeat{{"start", "", 10 * time.Minute}, {"", "", 5 * time.Minute}}, goplum.StateGood, "", 5 * time.Minute},
+		{"FailedDuration", []heartbeat{{"start", "", 10 * time.Minute}, {"fail", "", 8 * time.Minute}}, goplum.StateFailing, "Job failed", 2 * time.Minute},
+		{"DurationClearedWithoutStart", []heartbeat{{"start", "", 10 * time.Minute}, {"", "", 5 * time.Minute}, {"", "", time.Minute}}, goplum.StateGood, "", 0},
+		{"Running", []heartbeat{{"", "", 30 * time.Minute}, {"start", "", 10 * time.Minute}}, goplum.StateGood, "", 0},
+		{"NeverFinished", []heartbeat{{"", "", 30 * time.Minute}, {"start", "", 20 * time.Minute}}, goplum.StateFailing, "Job started 20m0s ago and has not finished", 0},
+		{"Missed", []heartbeat{{"", "", 2 * time.Hour}}, goplum.StateFailing, "No heartbeat received in 2h", 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check := (&Plugin{}).Check("received").(*ReceivedCheck)
+			check.ID = testId
+			check.Within = time.Hour
+			check.MaxDuration = 15 * time.Minute
+			require.NoError(t, check.Validate())
+
+			for _, h := range test.heartbeats {
+				check.record(h.action, h.body, time.Now().Add(-h.ago))
+			}
+
+			result := check.Execute(context.Background())
+			assert.Equal(t, test.expected, result.State)
+			assert.True(t, strings.HasPrefix(result.Detail, test.detail), "unexpected detail: %s", result.Detail)
+			if test.duration > 0 {
+				assert.Equal(t, test.duration, result.Facts[Duration].(time.Duration).Round(time.Second))
+			} else {
+				assert.Nil(t, result.Facts)
+			}
+		})
+	}
+}
+
+func TestReceivedCheck_DefaultMaxDuration(t *testing.T) {
+	check := (&Plugin{}).Check("received").(*ReceivedCheck)
+	check.ID = testId
+	check.Within = time.Hour
+	require.NoError(t, check.Validate())
+	assert.Equal(t, time.Hour, check.MaxDuration)
+
+	check.record(actionSuccess, "", time.Now().Add(-62*time.Minute))
+	check.record(actionStart, "", time.Now().Add(-61*time.Minute))
+	result := check.Execute(context.Background())
+	assert.Equal(t, goplum.StateFailing, result.State)
+	assert.Equal(t, "Job started 1h1m0s ago and has not finished", result.Detail)
+
+	scheduled := (&Plugin{}).Check("received").(*ReceivedCheck)
+	scheduled.ID = testId
+	scheduled.Schedule = "@daily"
+	scheduled.Grace = 30 * time.Minute
+	require.NoError(t, scheduled.Validate())
+	assert.Equal(t, 30*time.Minute, scheduled.MaxDuration)
+}
+
+func TestPlugin_ServeHTTP(t *testing.T) {
+	plugin := &Plugin{Path: "/heartbeat/"}
+	check := plugin.Check("received").(*ReceivedCheck)
+	check.ID = testId
+	check.Within = time.Hour
+	require.NoError(t, check.Validate())
+	plugin.RegisterChecks([]goplum.Check{check})
+
+	tests := []struct {
+		name   string
+		path   string
+		body   string
+		status int
+		state  goplum.CheckState
+		detail string
+	}{
+		{"UnknownPrefix", "/other/" + testId, "", http.StatusNotFound, goplum.StateIndeterminate, ""},
+		{"UnknownId", "/heartbeat/00000000000000000000000000000000", "", http.StatusNotFound, goplum.StateIndeterminate, ""},
+		{"UnknownAction", "/heartbeat/" + testId + "/pause", "", http.StatusNotFound, goplum.StateIndeterminate, ""},
+		{"Start", "/heartbeat/" + testId + "/start", "", http.StatusAccepted, goplum.StateIndeterminate, ""},
+		{"Fail", "/heartbeat/" + strings.ToUpper(testId) + "/fail", "Disk full\n", http.StatusAccepted, goplum.StateFailing, "Job failed: Disk full"},
+		{"Success", "/heartbeat/" + testId, "Backed up 12 files", http.StatusAccepted, goplum.StateGood, "Backed up 12 files"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			plugin.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, test.path, strings.NewReader(test.body)))
+			assert.Equal(t, test.status, recorder.Code)
+
+			result := check.Execute(context.Background())
+			assert.Equal(t, test.state, result.State)
+			if test.state != goplum.StateIndeterminate {
+				assert.Equal(t, test.detail, result.Detail)
+			}
+		})
+	}
+}
+
+func TestPlugin_ServeHTTPWithToken(t *testing.T) {
+	plugin := &Plugin{Path: "/heartbeat/"}
+	check := plugin.Check("received").(*ReceivedCheck)
+	check.ID = testId
+	check.Within = time.Hour
+	check.Token = "s3cret"
+	require.NoError(t, check.Validate())
+	plugin.RegisterChecks([]goplum.Check{check})
+
+	tests := []struct {
+		name   string
+		path   string
+		header string
+		status int
+	}{
+		{"Missing", "/heartbeat/" + testId, "", http.StatusUnauthorized},
+		{"WrongHeader", "/heartbeat/" + testId, "Bearer wrong", http.StatusUnauthorized},
+		{"WrongQuery", "/heartbeat/" + testId + "?token=wrong", "", http.StatusUnauthorized},
+		{"NotBearer", "/heartbeat/" + testId, "Basic s3cret", http.StatusUnauthorized},
+		{"Header", "/heartbeat/" + testId, "Bearer s3cret", http.StatusAccepted},
+		{"Query", "/heartbeat/" + testId + "/start?token=s3cret", "", http.StatusAccepted},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, test.path, nil)
+			if test.header != "" {
+				request.Header.Set("Authorization", test.header)
+			}
+
+			recorder := httptest.NewRecorder()
+			plugin.ServeHTTP(recorder, request)
+			assert.Equal(t, test.status, recorder.Code)
+		})
+	}
+
+	state := check.Save().(SavedState)
+	assert.False(t, state.Received.IsZero(), "authorised heartbeat should be recorded")
+	assert.False(t, state.Started.IsZero(), "authorised start heartbeat should be recorded")
+}
+
+func TestPlugin_RegisterChecksReplacesExisting(t *testing.T) {
+	const otherId = "0123456789abcdef0123456789abcdef"
+
+	plugin := &Plugin{Path: "/heartbeat/"}
+	check := plugin.Check("received").(*ReceivedCheck)
+	check.ID = testId
+	check.Within = time.Hour
+	require.NoError(t, check.Validate())
+
+	other := plugin.Check("received").(*ReceivedCheck)
+	other.ID = otherId
+	other.Within = time.Hour
+	require.NoError(t, other.Validate())
+
+	send := func(id string) int {
+		recorder := httptest.NewRecorder()
+		plugin.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/heartbeat/"+id, nil))
+		return recorder.Code
+	}
+
+	assert.Equal(t, http.StatusNotFound, send(testId), "checks should not receive heartbeats until registered")
+
+	plugin.RegisterChecks([]goplum.Check{check, other})
+	assert.Equal(t, http.StatusAccepted, send(testId))
+	assert.Equal(t, http.StatusAccepted, send(otherId))
+
+	plugin.RegisterChecks([]goplum.Check{other})
+	assert.Equal(t, http.StatusNotFound, send(testId))
+	assert.Equal(t, http.StatusAccepted, send(otherId))
+}
+
+func TestReceivedCheck_Schedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+		created  time.Duration
+		received time.Duration
+		expected goplum.CheckState
+		detail   string
+	}{
+		{"ReceivedSinceDue", "* * * * *", time.Hour, 5 * time.Minute, goplum.StateGood, ""},
+		{"MissedSinceDue", "* * * * *", time.Hour, 20 * time.Minute, goplum.StateFailing, "No heartbeat received since job was due at"},
+		{"NeverReceived", "* * * * *", time.Hour, 0, goplum.StateFailing, "No heartbeat received since job was due at"},
+		{"NotDueSinceCreated", "* * * * *", time.Minute, 0, goplum.StateIndeterminate, "No heartbeat received since monitoring started"},
+		{"DailyReceived", "30 2 * * *", 49 * time.Hour, time.Minute, goplum.StateGood, ""},
+		{"DailyMissed", "30 2 * * *", 49 * time.Hour, 0, goplum.StateFailing, "No heartbeat received since job was due at"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check := (&Plugin{}).Check("received").(*ReceivedCheck)
+			check.ID = testId
+			check.Schedule = test.schedule
+			check.Grace = 10 * time.Minute
+			require.NoError(t, check.Validate())
+
+			check.created = time.Now().Add(-test.created)
+			if test.received > 0 {
+				check.record(actionSuccess, "", time.Now().Add(-test.received))
+			}
+
+			result := check.Execute(context.Background())
+			assert.Equal(t, test.expected, result.State)
+			assert.True(t, strings.HasPrefix(result.Detail, test.detail), "unexpected detail: %s", result.Detail)
+		})
+	}
+}
+
+func TestReceivedCheck_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		check func(c *ReceivedCheck)
+		error string
+	}{
+		{"InvalidId", func(c *ReceivedCheck) { c.ID = "abc" }, "id must be a 32-character hexadecimal string"},
+		{"ShortWithin", func(c *ReceivedCheck) { c.Within = time.Second }, "within must be at least 30 seconds"},
+		{"WithinAndSchedule", func(c *ReceivedCheck) { c.Schedule = "@daily" }, "only one of within and schedule may be specified"},
+		{"InvalidSchedule", func(c *ReceivedCheck) { c.Within = 0; c.Schedule = "* * *" }, "invalid cron expression"},
+		{"NegativeGrace", func(c *ReceivedCheck) { c.Within = 0; c.Schedule = "@daily"; c.Grace = -time.Minute }, "grace must not be negative"},
+		{"NegativeMaxDuration", func(c *ReceivedCheck) { c.MaxDuration = -time.Minute }, "max_duration must not be negative"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check := (&Plugin{}).Check("received").(*ReceivedCheck)
+			check.ID = testId
+			check.Within = time.Hour
+			test.check(check)
+
+			err := check.Validate()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), test.error)
+		})
+	}
+}
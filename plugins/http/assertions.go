@@ -0,0 +1,328 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"chameth.com/goplum"
+)
+
+// Operators that may be used in assertions. Two-character operators must come before their one-character
+// prefixes so that they're matched first.
+var assertionOperators = []string{"==", "!=", "<=", ">=", "~=", "<", ">"}
+
+// assertion is a single expectation about part of a response, such as `$.status == "ok"` or
+// `Content-Type ~= json`. An assertion with no operator simply requires that the subject is present.
+type assertion struct {
+	text     string
+	subject  string
+	operator string
+	value    any
+	regexp   *regexp.Regexp
+}
+
+// parseAssertion parses an assertion in the format `<subject> [<operator> <value>]`. Values are parsed as JSON
+// literals where possible, and otherwise treated as plain strings. For the `~=` operator, the value is a
+// regular expression.
+func parseAssertion(text string) (*assertion, error) {
+	a := &assertion{text: text}
+
+	var raw string
+	index, operator := findOperator(text)
+	if index == -1 {
+		a.subject = strings.TrimSpace(text)
+	} else {
+		a.subject = strings.TrimSpace(text[:index])
+		a.operator = operator
+		raw = strings.TrimSpace(text[index+len(operator):])
+		if len(raw) == 0 {
+			return nil, fmt.Errorf("invalid assertion %q: missing value", text)
+		}
+
+		if err := json.Unmarshal([]byte(raw), &a.value); err != nil {
+			a.value = raw
+		}
+	}
+
+	if len(a.subject) == 0 {
+		return nil, fmt.Errorf("invalid assertion %q: missing subject", text)
+	}
+
+	switch a.operator {
+	case "<", "<=", ">", ">=":
+		if _, ok := a.value.(float64); !ok {
+			return nil, fmt.Errorf("invalid assertion %q: %s requires a numeric value", text, a.operator)
+		}
+	case "~=":
+		pattern, ok := a.value.(string)
+		if !ok {
+			pattern = raw
+		}
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid assertion %q: %v", text, err)
+		}
+		a.regexp = re
+	}
+
+	return a, nil
+}
+
+// findOperator returns the position and value of the first operator in the text, ignoring anything in quotes.
+func findOperator(text string) (int, string) {
+	var quote rune
+	for i, r := range text {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		default:
+			for _, op := range assertionOperators {
+				if strings.HasPrefix(text[i:], op) {
+					return i, op
+				}
+			}
+		}
+	}
+	return -1, ""
+}
+
+// check tests the assertion against the given value, returning an error describing the failure if it doesn't hold.
+func (a *assertion) check(actual any, found bool) error {
+	if !found {
+		return fmt.Errorf("assertion failed: %s (%s not found)", a.text, a.subject)
+	}
+
+	var ok bool
+	switch a.operator {
+	case "":
+		ok = true
+	case "==":
+		ok = equal(actual, a.value)
+	case "!=":
+		ok = !equal(actual, a.value)
+	case "~=":
+		ok = a.regexp.MatchString(format(actual))
+	default:
+		n, isNumber := number(actual)
+		if !isNumber {
+			return fmt.Errorf("assertion failed: %s (got %s, which is not a number)", a.text, describe(actual))
+		}
+
+		expected := a.value.(float64)
+		switch a.operator {
+		case "<":
+			ok = n < expected
+		case "<=":
+			ok = n <= expected
+		case ">":
+			ok = n > expected
+		case ">=":
+			ok = n >= expected
+		}
+	}
+
+	if !ok {
+		return fmt.Errorf("assertion failed: %s (got %s)", a.text, describe(actual))
+	}
+	return nil
+}
+
+// equal compares a value from a response with an expected value. Strings that look like numbers are compared
+// numerically with numeric values, as header values are always strings.
+func equal(actual, expected any) bool {
+	if e, ok := expected.(float64); ok {
+		n, ok := number(actual)
+		return ok && n == e
+	}
+	return reflect.DeepEqual(actual, expected)
+}
+
+// number converts the value to a float, if it is a number or a string containing one.
+func number(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// format converts the value to a string for matching against regular expressions. Strings are used as-is, and
+// anything else is encoded as JSON.
+func format(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	b, _ := json.Marshal(value)
+	return string(b)
+}
+
+// describe formats the value for use in a failure message.
+func describe(value any) string {
+	b, _ := json.Marshal(value)
+	return string(b)
+}
+
+// parseAssertions parses each of the given assertions, and validates their subjects using the given function.
+func parseAssertions(texts []string, validate func(subject string) error) ([]*assertion, error) {
+	var res []*assertion
+	for _, text := range texts {
+		a, err := parseAssertion(text)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := validate(a.subject); err != nil {
+			return nil, fmt.Errorf("invalid assertion %q: %v", text, err)
+		}
+
+		res = append(res, a)
+	}
+	return res, nil
+}
+
+// checkHeaders tests each of the header assertions against the response headers. Values of any headers that
+// are found are added to the facts.
+func checkHeaders(assertions []*assertion, header http.Header, facts map[goplum.Fact]any) error {
+	for _, a := range assertions {
+		values := header.Values(a.subject)
+		value := strings.Join(values, ", ")
+		if len(values) > 0 {
+			facts[headerFact(a.subject)] = value
+		}
+
+		if err := a.check(value, len(values) > 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkJson tests each of the JSON path assertions against the document. Any values that are found are added
+// to the facts.
+func checkJson(assertions []*assertion, doc any, facts map[goplum.Fact]any) error {
+	for _, a := range assertions {
+		path, _ := parseJsonPath(a.subject)
+		value, found := path.lookup(doc)
+		if found {
+			facts[jsonFact(a.subject)] = factFor(value)
+		}
+
+		if err := a.check(value, found); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// factFor converts a JSON value to a type suitable for use as a fact. Objects and arrays are encoded as JSON.
+func factFor(value any) any {
+	switch value.(type) {
+	case string, float64, bool:
+		return value
+	default:
+		return describe(value)
+	}
+}
+
+func headerFact(name string) goplum.Fact {
+	return goplum.Fact("chameth.com/goplum/plugins/http#header:" + strings.ToLower(name))
+}
+
+func jsonFact(path string) goplum.Fact {
+	return goplum.Fact("chameth.com/goplum/plugins/http#json:" + path)
+}
+
+func regexFact(group string) goplum.Fact {
+	return goplum.Fact("chameth.com/goplum/plugins/http#regex:" + group)
+}
+
+// jsonPath is a parsed JSON path expression. Each element is either a string (an object key) or an int (an
+// array index, which may be negative to count from the end).
+type jsonPath []any
+
+// parseJsonPath parses a simple JSON path expression, such as `$.items[0].name` or `$['key with spaces']`.
+// Wildcards, filters and recursive descent are not supported.
+func parseJsonPath(path string) (jsonPath, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("JSON paths must start with '$'")
+	}
+
+	var res jsonPath
+	rest := path[1:]
+	for len(rest) > 0 {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end == -1 {
+				end = len(rest) - 1
+			}
+			key := rest[1 : end+1]
+			if len(key) == 0 {
+				return nil, fmt.Errorf("empty key in JSON path")
+			}
+			res = append(res, key)
+			rest = rest[end+1:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated '[' in JSON path")
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				res = append(res, inner[1:len(inner)-1])
+			} else if index, err := strconv.Atoi(inner); err == nil {
+				res = append(res, index)
+			} else {
+				return nil, fmt.Errorf("invalid index '%s' in JSON path", inner)
+			}
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected '%c' in JSON path", rest[0])
+		}
+	}
+	return res, nil
+}
+
+// lookup finds the value referenced by the path in the given document.
+func (p jsonPath) lookup(doc any) (any, bool) {
+	current := doc
+	for _, element := range p {
+		switch e := element.(type) {
+		case string:
+			obj, ok := current.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			if current, ok = obj[e]; !ok {
+				return nil, false
+			}
+		case int:
+			arr, ok := current.([]any)
+			if !ok {
+				return nil, false
+			}
+			if e < 0 {
+				e += len(arr)
+			}
+			if e < 0 || e >= len(arr) {
+				return nil, false
+			}
+			current = arr[e]
+		}
+	}
+	return current, true
+}
@@ -10,6 +10,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -45,6 +47,15 @@ func (p Plugin) Check(kind string) goplum.Check {
 			MinStatusCode:   100,
 			MaxStatusCode:   399,
 		}
+	case "request":
+		return RequestCheck{
+			GetCheck: GetCheck{
+				ContentExpected: true,
+				MinStatusCode:   100,
+				MaxStatusCode:   399,
+			},
+			Method: http.MethodGet,
+		}
 	case "healthcheck":
 		return HealthCheck{}
 	default:
@@ -52,59 +63,205 @@ func (p Plugin) Check(kind string) goplum.Check {
 	}
 }
 
+// defaultMaxRedirects is the number of redirects followed by checks unless configured otherwise. This matches
+// the default behaviour of http.Client.
+const defaultMaxRedirects = 10
+
+// defaultMaxBodySize is the number of bytes of a response body that checks will read unless configured otherwise.
+const defaultMaxBodySize = 10 * 1024 * 1024
+
+var (
+	// BodySize is the size of the response body in bytes, if known. Its value is an int64.
+	BodySize goplum.Fact = "chameth.com/goplum/plugins/http#body_size"
+
+	// BytesRead is the number of bytes of the response body that were read before the check finished. Its
+	// value is an int64.
+	BytesRead goplum.Fact = "chameth.com/goplum/plugins/http#bytes_read"
+)
+
+// Policies that can be used for following redirects.
+const (
+	redirectsFollow   = "follow"
+	redirectsSameHost = "same_host"
+	redirectsNone     = "none"
+)
+
 type Credentials struct {
 	Username string
 	Password string
+	Token    string
 }
 
 type BaseCheck struct {
-	Url  string
-	Auth Credentials
+	Url          string
+	Auth         Credentials
+	Headers      []string
+	Redirects    string
+	MaxRedirects int `config:"max_redirects"`
+
+	MaxResponseTime     time.Duration `config:"max_response_time"`
+	WarningResponseTime time.Duration `config:"warning_response_time"`
+
+	ClientOptions `config:",squash"`
+}
+
+// newRequest creates a request to the check's URL, with any configured authentication and headers.
+func (b BaseCheck) newRequest(ctx context.Context, method string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, b.Url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b.Auth.Token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+b.Auth.Token)
+	} else if len(b.Auth.Username) > 0 || len(b.Auth.Password) > 0 {
+		req.SetBasicAuth(b.Auth.Username, b.Auth.Password)
+	}
+
+	for _, h := range b.Headers {
+		name, value, _ := strings.Cut(h, ":")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if http.CanonicalHeaderKey(name) == "Host" {
+			// The Host header is ignored by the client; it has to be set on the request itself.
+			req.Host = value
+		} else {
+			req.Header.Add(name, value)
+		}
+	}
+
+	return req, nil
+}
+
+// client returns a HTTP client that follows redirects and connects according to the check's settings.
+func (b BaseCheck) client() (*http.Client, error) {
+	limit := b.MaxRedirects
+	if limit <= 0 {
+		limit = defaultMaxRedirects
+	}
+
+	c := client
+	if b.ClientOptions.customised() {
+		transport, err := b.ClientOptions.transport()
+		if err != nil {
+			return nil, err
+		}
+		c.Transport = transport
+	}
+
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		switch {
+		case b.Redirects == redirectsNone:
+			return http.ErrUseLastResponse
+		case b.Redirects == redirectsSameHost && req.URL.Host != via[0].URL.Host:
+			return http.ErrUseLastResponse
+		case len(via) >= limit:
+			return fmt.Errorf("stopped after %d redirects", limit)
+		default:
+			return nil
+		}
+	}
+	return &c, nil
+}
+
+// checkResponseTime changes the result to failing or warning if the response took longer than the configured
+// thresholds. Results that are already in a worse state are returned unchanged.
+func (b BaseCheck) checkResponseTime(result goplum.Result, elapsed time.Duration) goplum.Result {
+	if result.State == goplum.StateIndeterminate {
+		return result
+	}
+
+	if b.MaxResponseTime > 0 && elapsed > b.MaxResponseTime && result.State != goplum.StateFailing {
+		return goplum.FailingResult("Response took %s, longer than the maximum of %s", elapsed.Round(time.Millisecond), b.MaxResponseTime)
+	}
+
+	if b.WarningResponseTime > 0 && elapsed > b.WarningResponseTime && result.State == goplum.StateGood {
+		return goplum.WarningResult("Response took %s, longer than the warning threshold of %s", elapsed.Round(time.Millisecond), b.WarningResponseTime)
+	}
+
+	return result
+}
+
+func (b BaseCheck) Validate() error {
+	if len(b.Url) == 0 {
+		return fmt.Errorf("missing required argument: url")
+	}
+
+	for _, h := range b.Headers {
+		if name, _, ok := strings.Cut(h, ":"); !ok || len(strings.TrimSpace(name)) == 0 {
+			return fmt.Errorf("invalid header %q, expected format \"Name: Value\"", h)
+		}
+	}
+
+	switch b.Redirects {
+	case "", redirectsFollow, redirectsSameHost, redirectsNone:
+	default:
+		return fmt.Errorf("invalid value for redirects: %q, expected %q, %q or %q", b.Redirects, redirectsFollow, redirectsSameHost, redirectsNone)
+	}
+
+	if len(b.Auth.Token) > 0 && (len(b.Auth.Username) > 0 || len(b.Auth.Password) > 0) {
+		return fmt.Errorf("auth may contain either a token or a username and password, not both")
+	}
+
+	if b.MaxResponseTime > 0 && b.WarningResponseTime >= b.MaxResponseTime {
+		return fmt.Errorf("warning_response_time must be less than max_response_time")
+	}
+
+	return b.ClientOptions.Validate()
 }
 
 type GetCheck struct {
 	BaseCheck           `config:",squash"`
 	Content             string
 	ContentExpected     bool          `config:"content_expected"`
+	ContentRegex        string        `config:"content_regex"`
+	JsonAssertions      []string      `config:"json_assertions"`
+	HeaderAssertions    []string      `config:"header_assertions"`
 	CertificateValidity time.Duration `config:"certificate_validity"`
 	MinStatusCode       int           `config:"min_status_code"`
 	MaxStatusCode       int           `config:"max_status_code"`
+	MaxBodySize         int64         `config:"max_body_size"`
 }
 
 func (g GetCheck) Execute(ctx context.Context) goplum.Result {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.Url, http.NoBody)
+	return g.execute(ctx, http.MethodGet, http.NoBody)
+}
+
+// execute sends a request with the given method and body, and checks the response against the configured
+// expectations.
+func (g GetCheck) execute(ctx context.Context, method string, body io.Reader) goplum.Result {
+	req, err := g.newRequest(ctx, method, body)
 	if err != nil {
-		goplum.FailingResult("Error building request: %v", err)
+		return goplum.FailingResult("Error building request: %v", err)
 	}
 
-	if len(g.Auth.Username) > 0 || len(g.Auth.Password) > 0 {
-		req.SetBasicAuth(g.Auth.Username, g.Auth.Password)
+	c, err := g.client()
+	if err != nil {
+		return goplum.FailingResult("Error configuring client: %v", err)
 	}
 
-	r, err := client.Do(req)
-
+	t := &timings{}
+	r, err := c.Do(t.trace(req))
 	if err != nil {
 		return goplum.FailingResult("Error making request: %v", err)
-	} else if r.StatusCode < g.MinStatusCode || r.StatusCode > g.MaxStatusCode {
-		return goplum.FailingResult("Bad status code: %d", r.StatusCode)
 	}
 
 	defer r.Body.Close()
 
-	if len(g.Content) > 0 {
-		content, err := io.ReadAll(r.Body)
-		if err != nil {
-			return goplum.FailingResult("Error reading response body: %v", err)
-		}
+	facts := map[goplum.Fact]any{}
+	result := g.check(r, facts)
+	result = g.checkResponseTime(result, t.finish(facts))
+	result.Facts = facts
+	return result
+}
 
-		// TODO: It would be nice to scan the body instead of having to read it all into memory
-		// TODO: Add options around case sensitivity/consider allowing regexp
-		found := strings.Contains(string(content), g.Content)
-		if !found && g.ContentExpected {
-			return goplum.FailingResult("Body does not contain '%s'", g.Content)
-		} else if found && !g.ContentExpected {
-			return goplum.FailingResult("Body contains '%s'", g.Content)
-		}
+// check checks the response against the configured expectations.
+func (g GetCheck) check(r *http.Response, facts map[goplum.Fact]any) goplum.Result {
+	if r.StatusCode < g.MinStatusCode || r.StatusCode > g.MaxStatusCode {
+		return goplum.FailingResult("Bad status code: %d", r.StatusCode)
+	}
+
+	if err := g.checkResponse(r, facts); err != nil {
+		return goplum.FailingResult("%v", err)
 	}
 
 	if g.CertificateValidity > 0 {
@@ -121,9 +278,222 @@ func (g GetCheck) Execute(ctx context.Context) goplum.Result {
 	return goplum.GoodResult()
 }
 
+// checkResponse checks the response's headers and body against the configured content and assertions, adding
+// any values extracted from the response to the facts.
+func (g GetCheck) checkResponse(r *http.Response, facts map[goplum.Fact]any) error {
+	headerAssertions, _ := parseAssertions(g.HeaderAssertions, validateHeaderName)
+	if err := checkHeaders(headerAssertions, r.Header, facts); err != nil {
+		return err
+	}
+
+	if r.ContentLength >= 0 {
+		facts[BodySize] = r.ContentLength
+	}
+
+	if len(g.Content) == 0 && len(g.ContentRegex) == 0 && len(g.JsonAssertions) == 0 {
+		return nil
+	}
+
+	// The whole body is only kept in memory if it's needed for regexps or JSON assertions; otherwise we can stop
+	// reading as soon as the content is found.
+	keep := len(g.ContentRegex) > 0 || len(g.JsonAssertions) > 0
+	scan, err := g.scanBody(r.Body, keep)
+	facts[BytesRead] = scan.read
+	if scan.complete {
+		facts[BodySize] = scan.read
+	}
+
+	if err != nil {
+		return fmt.Errorf("Error reading response body: %v", err)
+	}
+
+	if !scan.complete && (keep || !scan.found) {
+		return fmt.Errorf("Body exceeds max_body_size of %d bytes", g.maxBodySize())
+	}
+
+	if len(g.Content) > 0 {
+		if !scan.found && g.ContentExpected {
+			return fmt.Errorf("Body does not contain '%s'", g.Content)
+		} else if scan.found && !g.ContentExpected {
+			return fmt.Errorf("Body contains '%s'", g.Content)
+		}
+	}
+
+	if len(g.ContentRegex) > 0 {
+		re := regexp.MustCompile(g.ContentRegex)
+		match := re.FindSubmatch(scan.content)
+		for i, name := range re.SubexpNames() {
+			if len(name) > 0 && match != nil {
+				facts[regexFact(name)] = string(match[i])
+			}
+		}
+
+		if match == nil && g.ContentExpected {
+			return fmt.Errorf("Body does not match '%s'", g.ContentRegex)
+		} else if match != nil && !g.ContentExpected {
+			return fmt.Errorf("Body matches '%s'", g.ContentRegex)
+		}
+	}
+
+	if len(g.JsonAssertions) > 0 {
+		var doc any
+		if err := json.Unmarshal(scan.content, &doc); err != nil {
+			return fmt.Errorf("Error decoding response body as JSON: %v", err)
+		}
+
+		jsonAssertions, _ := parseAssertions(g.JsonAssertions, validateJsonPath)
+		if err := checkJson(jsonAssertions, doc, facts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// bodyScan contains the result of scanning a response body.
+type bodyScan struct {
+	// content is the body that was read, if it was kept.
+	content []byte
+	// read is the number of bytes of the body that were read.
+	read int64
+	// found indicates whether the check's content was found in the body.
+	found bool
+	// complete indicates that the entire body was read.
+	complete bool
+}
+
+// scanBody reads the response body in chunks, searching for the check's content as it goes. Reading stops once
+// the content is found (unless the body is being kept), or once the max_body_size has been exceeded.
+func (g GetCheck) scanBody(body io.Reader, keep bool) (*bodyScan, error) {
+	limit := g.maxBodySize()
+	reader := io.LimitReader(body, limit+1)
+	needle := []byte(g.Content)
+	res := &bodyScan{}
+
+	buf := make([]byte, 32*1024)
+	var window []byte
+	for {
+		n, err := reader.Read(buf)
+		chunk := buf[:n]
+		if res.read+int64(n) > limit {
+			chunk = chunk[:limit-res.read]
+		}
+		res.read += int64(len(chunk))
+
+		if keep {
+			res.content = append(res.content, chunk...)
+		}
+
+		if len(needle) > 0 && !res.found {
+			// Keep enough of the previous chunk that we find the content if it straddles two chunks.
+			window = append(window, chunk...)
+			if bytes.Contains(window, needle) {
+				res.found = true
+			} else if overlap := len(needle) - 1; len(window) > overlap {
+				window = append(window[:0], window[len(window)-overlap:]...)
+			}
+		}
+
+		if len(chunk) < n {
+			return res, nil
+		}
+
+		if err == io.EOF {
+			res.complete = true
+			return res, nil
+		} else if err != nil {
+			return res, err
+		}
+
+		if res.found && !keep {
+			return res, nil
+		}
+	}
+}
+
+// maxBodySize returns the maximum number of bytes that will be read from a response body.
+func (g GetCheck) maxBodySize() int64 {
+	if g.MaxBodySize <= 0 {
+		return defaultMaxBodySize
+	}
+	return g.MaxBodySize
+}
+
 func (g GetCheck) Validate() error {
-	if len(g.Url) == 0 {
-		return fmt.Errorf("missing required argument: url")
+	if err := g.BaseCheck.Validate(); err != nil {
+		return err
+	}
+
+	if len(g.ContentRegex) > 0 {
+		if _, err := regexp.Compile(g.ContentRegex); err != nil {
+			return fmt.Errorf("invalid content_regex: %v", err)
+		}
+	}
+
+	if _, err := parseAssertions(g.JsonAssertions, validateJsonPath); err != nil {
+		return err
+	}
+
+	if _, err := parseAssertions(g.HeaderAssertions, validateHeaderName); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateJsonPath(subject string) error {
+	_, err := parseJsonPath(subject)
+	return err
+}
+
+func validateHeaderName(subject string) error {
+	if strings.ContainsAny(subject, " \t:") {
+		return fmt.Errorf("invalid header name")
+	}
+	return nil
+}
+
+// RequestCheck is a GetCheck that can use any HTTP method, and send a request body.
+type RequestCheck struct {
+	GetCheck `config:",squash"`
+	Method   string
+	Body     string
+	BodyFile string `config:"body_file"`
+}
+
+func (r RequestCheck) Execute(ctx context.Context) goplum.Result {
+	body := io.Reader(http.NoBody)
+	if len(r.Body) > 0 {
+		body = strings.NewReader(r.Body)
+	} else if len(r.BodyFile) > 0 {
+		// Read the whole file so the request can be replayed if it's redirected.
+		b, err := os.ReadFile(r.BodyFile)
+		if err != nil {
+			return goplum.FailingResult("Error reading request body: %v", err)
+		}
+		body = bytes.NewReader(b)
+	}
+
+	return r.execute(ctx, strings.ToUpper(r.Method), body)
+}
+
+func (r RequestCheck) Validate() error {
+	if err := r.GetCheck.Validate(); err != nil {
+		return err
+	}
+
+	if len(r.Method) == 0 {
+		return fmt.Errorf("missing required argument: method")
+	}
+
+	if len(r.Body) > 0 && len(r.BodyFile) > 0 {
+		return fmt.Errorf("only one of body and body_file may be specified")
+	}
+
+	if len(r.BodyFile) > 0 {
+		if _, err := os.Stat(r.BodyFile); err != nil {
+			return fmt.Errorf("unable to read body_file: %v", err)
+		}
 	}
 
 	return nil
@@ -135,23 +505,33 @@ type HealthCheck struct {
 }
 
 func (h HealthCheck) Execute(ctx context.Context) goplum.Result {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.Url, http.NoBody)
+	req, err := h.newRequest(ctx, http.MethodGet, http.NoBody)
 	if err != nil {
-		goplum.FailingResult("Error building request: %v", err)
+		return goplum.FailingResult("Error building request: %v", err)
 	}
 
-	if len(h.Auth.Username) > 0 || len(h.Auth.Password) > 0 {
-		req.SetBasicAuth(h.Auth.Username, h.Auth.Password)
+	c, err := h.client()
+	if err != nil {
+		return goplum.FailingResult("Error configuring client: %v", err)
 	}
 
-	r, err := client.Do(req)
-
+	t := &timings{}
+	r, err := c.Do(t.trace(req))
 	if err != nil {
 		return goplum.FailingResult("Error making request: %v", err)
 	}
 
 	defer r.Body.Close()
 
+	facts := map[goplum.Fact]any{}
+	result := h.check(r)
+	result = h.checkResponseTime(result, t.finish(facts))
+	result.Facts = facts
+	return result
+}
+
+// check decodes the healthcheck response and converts it to a result.
+func (h HealthCheck) check(r *http.Response) goplum.Result {
 	res := &health.Health{}
 	if err := json.NewDecoder(r.Body).Decode(res); err != nil {
 		return goplum.FailingResult("Error decoding response: %v", err)
@@ -160,12 +540,12 @@ func (h HealthCheck) Execute(ctx context.Context) goplum.Result {
 	status := h.convert(res.Status)
 	detail := res.Output
 
-	if status == goplum.StateGood && h.CheckComponents {
+	if (status == goplum.StateGood || status == goplum.StateWarning) && h.CheckComponents {
 		for name, checks := range res.Checks {
 			for i := range checks {
 				check := checks[i]
 				checkStatus := h.convert(check.Status)
-				if checkStatus > status {
+				if checkStatus.Severity() > status.Severity() {
 					status = checkStatus
 					detail = fmt.Sprintf("component %s: %s", name, check.Output)
 				}
@@ -177,11 +557,7 @@ func (h HealthCheck) Execute(ctx context.Context) goplum.Result {
 }
 
 func (h HealthCheck) Validate() error {
-	if len(h.Url) == 0 {
-		return fmt.Errorf("missing required argument: url")
-	}
-
-	return nil
+	return h.BaseCheck.Validate()
 }
 
 func (h HealthCheck) convert(status health.Status) goplum.CheckState {
@@ -191,7 +567,7 @@ func (h HealthCheck) convert(status health.Status) goplum.CheckState {
 	} else if lower == "fail" || lower == "error" || lower == "down" {
 		return goplum.StateFailing
 	} else if lower == "warn" {
-		return goplum.StateGood // TODO: Change when goplum implements warnings
+		return goplum.StateWarning
 	} else {
 		return goplum.StateIndeterminate
 	}
@@ -1,16 +1,33 @@
 package http
 
 import (
+	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"net/http/httptrace"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"chameth.com/goplum"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetCheck_FollowsRedirectsEachTime(t *testing.T) {
@@ -143,3 +160,633 @@ func TestWebHookAlert_ReturnsErrorOnInvalidHeader(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid header")
 }
+
+func TestHealthCheck_ReportsWarnings(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected goplum.CheckState
+	}{
+		{"Pass", `{"status":"pass"}`, goplum.StateGood},
+		{"Warn", `{"status":"warn","output":"disk filling up"}`, goplum.StateWarning},
+		{"Fail", `{"status":"fail"}`, goplum.StateFailing},
+		{"WarningComponent", `{"status":"pass","checks":{"disk":[{"status":"warn"}]}}`, goplum.StateWarning},
+		{"FailingComponentAfterWarning", `{"status":"warn","checks":{"db":[{"status":"fail"}]}}`, goplum.StateFailing},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(test.body))
+			}))
+			defer server.Close()
+
+			check := HealthCheck{BaseCheck: BaseCheck{Url: server.URL}, CheckComponents: true}
+			result := check.Execute(context.Background())
+			assert.Equal(t, test.expected, result.State)
+		})
+	}
+}
+
+func TestRequestCheck_SendsMethodHeadersAndBody(t *testing.T) {
+	var method, auth, contentType, body string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		auth = r.Header.Get("Authorization")
+		contentType = r.Header.Get("Content-Type")
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer server.Close()
+
+	check := Plugin{}.Check("request").(RequestCheck)
+	check.Url = server.URL
+	check.Method = "post"
+	check.Headers = []string{"Content-Type: application/json"}
+	check.Auth.Token = "token123"
+	check.Body = `{"query":"{ status }"}`
+	assert.NoError(t, check.Validate())
+
+	result := check.Execute(context.Background())
+	assert.Equal(t, goplum.StateGood, result.State)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "Bearer token123", auth)
+	assert.Equal(t, "application/json", contentType)
+	assert.Equal(t, `{"query":"{ status }"}`, body)
+}
+
+func TestRequestCheck_SendsBodyFromFile(t *testing.T) {
+	var body string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "body.json")
+	assert.NoError(t, os.WriteFile(path, []byte("from file"), 0600))
+
+	check := Plugin{}.Check("request").(RequestCheck)
+	check.Url = server.URL
+	check.Method = http.MethodPut
+	check.BodyFile = path
+	assert.NoError(t, check.Validate())
+
+	result := check.Execute(context.Background())
+	assert.Equal(t, goplum.StateGood, result.State)
+	assert.Equal(t, "from file", body)
+}
+
+func TestRequestCheck_ResendsBodyFromFileOnRedirect(t *testing.T) {
+	var body string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/new", http.StatusTemporaryRedirect)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "body.json")
+	assert.NoError(t, os.WriteFile(path, []byte("from file"), 0600))
+
+	check := Plugin{}.Check("request").(RequestCheck)
+	check.Url = server.URL + "/old"
+	check.Method = http.MethodPost
+	check.BodyFile = path
+	assert.NoError(t, check.Validate())
+
+	result := check.Execute(context.Background())
+	assert.Equal(t, goplum.StateGood, result.State, result.Detail)
+	assert.Equal(t, "from file", body)
+}
+
+func TestBaseCheck_SetsHostHeader(t *testing.T) {
+	var host, custom string
+
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		host = r.Host
+		custom = r.Header.Get("X-Custom")
+	}))
+	defer server.Close()
+
+	check := Plugin{}.Check("request").(RequestCheck)
+	check.Url = server.URL
+	check.Method = http.MethodGet
+	check.Headers = []string{"host: example.com", "X-Custom: value"}
+	assert.NoError(t, check.Validate())
+
+	result := check.Execute(context.Background())
+	assert.Equal(t, goplum.StateGood, result.State, result.Detail)
+	assert.Equal(t, "example.com", host)
+	assert.Equal(t, "value", custom)
+}
+
+func TestBaseCheck_RedirectPolicies(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer other.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/loop":
+			http.Redirect(w, r, "/loop", http.StatusFound)
+		case "/same":
+			http.Redirect(w, r, "/done", http.StatusFound)
+		case "/other":
+			http.Redirect(w, r, other.URL, http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		path         string
+		redirects    string
+		maxRedirects int
+		expected     goplum.CheckState
+		detail       string
+	}{
+		{"FollowDefault", "/other", "", 0, goplum.StateGood, ""},
+		{"NoneReturnsRedirect", "/same", redirectsNone, 0, goplum.StateFailing, "Bad status code: 302"},
+		{"SameHostFollowed", "/same", redirectsSameHost, 0, goplum.StateGood, ""},
+		{"SameHostNotFollowed", "/other", redirectsSameHost, 0, goplum.StateFailing, "Bad status code: 302"},
+		{"TooMany", "/loop", redirectsFollow, 3, goplum.StateFailing, "stopped after 3 redirects"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check := Plugin{}.Check("get").(GetCheck)
+			check.Url = server.URL + test.path
+			check.Redirects = test.redirects
+			check.MaxRedirects = test.maxRedirects
+			check.MaxStatusCode = 299
+			assert.NoError(t, check.Validate())
+
+			result := check.Execute(context.Background())
+			assert.Equal(t, test.expected, result.State)
+			assert.Contains(t, result.Detail, test.detail)
+		})
+	}
+}
+
+func TestBaseCheck_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		check BaseCheck
+		error string
+	}{
+		{"MissingUrl", BaseCheck{}, "missing required argument: url"},
+		{"InvalidHeader", BaseCheck{Url: "http://localhost", Headers: []string{"NoColon"}}, "invalid header"},
+		{"InvalidRedirects", BaseCheck{Url: "http://localhost", Redirects: "sometimes"}, "invalid value for redirects"},
+		{"TokenAndPassword", BaseCheck{Url: "http://localhost", Auth: Credentials{Username: "user", Token: "token"}}, "either a token"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.check.Validate()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), test.error)
+		})
+	}
+}
+
+func TestGetCheck_Assertions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("X-Queue-Length", "12")
+		_, _ = w.Write([]byte(`{"status":"ok","version":"1.4.2","queue":{"length":12},"nodes":[{"name":"a"},{"name":"b"}]}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		regex    string
+		json     []string
+		headers  []string
+		expected goplum.CheckState
+		detail   string
+		facts    map[goplum.Fact]any
+	}{
+		{
+			name:     "JsonEquals",
+			json:     []string{`$.status == "ok"`},
+			expected: goplum.StateGood,
+			facts:    map[goplum.Fact]any{jsonFact("$.status"): "ok"},
+		},
+		{
+			name:     "JsonNumeric",
+			json:     []string{`$.queue.length < 100`, `$.queue.length >= 12`},
+			expected: goplum.StateGood,
+			facts:    map[goplum.Fact]any{jsonFact("$.queue.length"): 12.0},
+		},
+		{
+			name:     "JsonIndexAndExists",
+			json:     []string{`$.nodes[-1].name == b`, `$['version']`},
+			expected: goplum.StateGood,
+			facts:    map[goplum.Fact]any{jsonFact("$.nodes[-1].name"): "b", jsonFact("$['version']"): "1.4.2"},
+		},
+		{
+			name:     "JsonFailure",
+			json:     []string{`$.status == "ok"`, `$.queue.length > 50`},
+			expected: goplum.StateFailing,
+			detail:   "assertion failed: $.queue.length > 50 (got 12)",
+		},
+		{
+			name:     "JsonMissing",
+			json:     []string{`$.nodes[5]`},
+			expected: goplum.StateFailing,
+			detail:   "assertion failed: $.nodes[5] ($.nodes[5] not found)",
+		},
+		{
+			name:     "Headers",
+			headers:  []string{`Content-Type ~= ^application/json`, `X-Queue-Length <= 20`},
+			expected: goplum.StateGood,
+			facts:    map[goplum.Fact]any{headerFact("Content-Type"): "application/json; charset=utf-8", headerFact("X-Queue-Length"): "12"},
+		},
+		{
+			name:     "HeaderFailure",
+			headers:  []string{`Cache-Control == "no-store"`},
+			expected: goplum.StateFailing,
+			detail:   "assertion failed: Cache-Control == \"no-store\" (Cache-Control not found)",
+		},
+		{
+			name:     "RegexWithGroups",
+			regex:    `"version":"(?P<major>\d+)\.(?P<minor>\d+)`,
+			expected: goplum.StateGood,
+			facts:    map[goplum.Fact]any{regexFact("major"): "1", regexFact("minor"): "4"},
+		},
+		{
+			name:     "RegexFailure",
+			regex:    `(?i)"status":"degraded"`,
+			expected: goplum.StateFailing,
+			detail:   "Body does not match '(?i)\"status\":\"degraded\"'",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check := Plugin{}.Check("get").(GetCheck)
+			check.Url = server.URL
+			check.ContentRegex = test.regex
+			check.JsonAssertions = test.json
+			check.HeaderAssertions = test.headers
+			assert.NoError(t, check.Validate())
+
+			result := check.Execute(context.Background())
+			assert.Equal(t, test.expected, result.State)
+			assert.Equal(t, test.detail, result.Detail)
+			for fact, value := range test.facts {
+				assert.Equal(t, value, result.Facts[fact])
+			}
+		})
+	}
+}
+
+func TestGetCheck_ValidateAssertions(t *testing.T) {
+	tests := []struct {
+		name  string
+		check GetCheck
+		error string
+	}{
+		{"InvalidRegex", GetCheck{ContentRegex: "(unclosed"}, "invalid content_regex"},
+		{"PathWithoutDollar", GetCheck{JsonAssertions: []string{"status == ok"}}, "must start with '$'"},
+		{"UnterminatedIndex", GetCheck{JsonAssertions: []string{"$.nodes[0 == ok"}}, "unterminated '['"},
+		{"NonNumericComparison", GetCheck{JsonAssertions: []string{"$.count > lots"}}, "requires a numeric value"},
+		{"MissingValue", GetCheck{JsonAssertions: []string{"$.status =="}}, "missing value"},
+		{"InvalidHeaderName", GetCheck{HeaderAssertions: []string{"Content Type == text/html"}}, "invalid header name"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.check.Url = "http://localhost"
+			err := test.check.Validate()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), test.error)
+		})
+	}
+}
+
+// endlessReader produces an endless stream of a repeated byte.
+type endlessReader struct{}
+
+func (endlessReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 'x'
+	}
+	return len(p), nil
+}
+
+func TestGetCheck_ScansBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/small":
+			_, _ = w.Write([]byte("hello world"))
+		case "/straddle":
+			// Put the content either side of the scanner's chunk boundary.
+			_, _ = w.Write(bytes.Repeat([]byte("x"), 32*1024-3))
+			_, _ = w.Write([]byte("needle"))
+			_, _ = w.Write(bytes.Repeat([]byte("x"), 1024))
+		case "/endless":
+			_, _ = w.Write([]byte("needle"))
+			_, _ = io.Copy(w, io.LimitReader(endlessReader{}, 100*1024*1024))
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		path     string
+		content  string
+		regex    string
+		maxSize  int64
+		expected goplum.CheckState
+		detail   string
+		maxRead  int64
+		bodySize any
+	}{
+		{"Small", "/small", "world", "", 0, goplum.StateGood, "", 11, int64(11)},
+		{"Straddle", "/straddle", "needle", "", 0, goplum.StateGood, "", 64 * 1024, nil},
+		{"NotFoundWithinLimit", "/small", "world", "", 8, goplum.StateFailing, "Body exceeds max_body_size of 8 bytes", 8, nil},
+		{"FoundWithinLimit", "/small", "hello", "", 8, goplum.StateGood, "", 8, nil},
+		{"RegexNeedsWholeBody", "/small", "", "hello", 8, goplum.StateFailing, "Body exceeds max_body_size of 8 bytes", 8, nil},
+		{"StopsEarly", "/endless", "needle", "", 0, goplum.StateGood, "", 64 * 1024, nil},
+		{"StopsAtLimit", "/endless", "missing", "", 64 * 1024, goplum.StateFailing, "Body exceeds max_body_size of 65536 bytes", 64 * 1024, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check := Plugin{}.Check("get").(GetCheck)
+			check.Url = server.URL + test.path
+			check.Content = test.content
+			check.ContentRegex = test.regex
+			check.MaxBodySize = test.maxSize
+
+			result := check.Execute(context.Background())
+			assert.Equal(t, test.expected, result.State)
+			assert.Equal(t, test.detail, result.Detail)
+			assert.LessOrEqual(t, result.Facts[BytesRead], test.maxRead)
+			if test.bodySize != nil {
+				assert.Equal(t, test.bodySize, result.Facts[BodySize])
+			}
+		})
+	}
+}
+
+// writeCertificate PEM-encodes the certificate and writes it to a file in a temporary directory.
+func writeCertificate(t *testing.T, cert *x509.Certificate) string {
+	path := filepath.Join(t.TempDir(), "cert.pem")
+	assert.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}), 0600))
+	return path
+}
+
+// newClientCertificate creates a self-signed client certificate, and writes it and its key to disk.
+func newClientCertificate(t *testing.T) (*x509.Certificate, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "goplum"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	keyPath := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+
+	return cert, writeCertificate(t, cert), keyPath
+}
+
+func TestClientOptions_Tls(t *testing.T) {
+	clientCert, certPath, keyPath := newClientCertificate(t)
+
+	var protocol, clientName string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		protocol = r.Proto
+		clientName = ""
+		if len(r.TLS.PeerCertificates) > 0 {
+			clientName = r.TLS.PeerCertificates[0].Subject.CommonName
+		}
+	}))
+	server.EnableHTTP2 = true
+	server.TLS = &tls.Config{ClientAuth: tls.VerifyClientCertIfGiven, ClientCAs: x509.NewCertPool()}
+	server.TLS.ClientCAs.AddCert(clientCert)
+	server.StartTLS()
+	defer server.Close()
+
+	caFile := writeCertificate(t, server.Certificate())
+
+	tests := []struct {
+		name     string
+		options  ClientOptions
+		expected goplum.CheckState
+		protocol string
+		client   string
+	}{
+		{"Untrusted", ClientOptions{}, goplum.StateFailing, "", ""},
+		{"CaFile", ClientOptions{CaFile: caFile}, goplum.StateGood, "HTTP/2.0", ""},
+		{"InsecureSkipVerify", ClientOptions{InsecureSkipVerify: true}, goplum.StateGood, "HTTP/2.0", ""},
+		{"ClientCertificate", ClientOptions{CaFile: caFile, ClientCertificate: certPath, ClientKey: keyPath}, goplum.StateGood, "HTTP/2.0", "goplum"},
+		{"Http1", ClientOptions{CaFile: caFile, HttpVersion: httpVersion1}, goplum.StateGood, "HTTP/1.1", ""},
+		{"Http2", ClientOptions{CaFile: caFile, HttpVersion: httpVersion2}, goplum.StateGood, "HTTP/2.0", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			protocol = ""
+			check := Plugin{}.Check("get").(GetCheck)
+			check.Url = server.URL
+			check.ClientOptions = test.options
+			require.NoError(t, check.Validate())
+
+			result := check.Execute(context.Background())
+			assert.Equal(t, test.expected, result.State, result.Detail)
+			assert.Equal(t, test.protocol, protocol)
+			if test.expected == goplum.StateGood {
+				assert.Equal(t, test.client, clientName)
+			}
+		})
+	}
+}
+
+func TestClientOptions_ProxyAndSourceAddress(t *testing.T) {
+	var proxied, remote string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		remote = r.RemoteAddr
+		_, _ = w.Write([]byte("via proxy"))
+	}))
+	defer proxy.Close()
+
+	check := Plugin{}.Check("get").(GetCheck)
+	check.Url = "http://service.internal/status"
+	check.Content = "via proxy"
+	check.Proxy = proxy.URL
+	check.SourceAddress = "127.0.0.1"
+	require.NoError(t, check.Validate())
+
+	result := check.Execute(context.Background())
+	assert.Equal(t, goplum.StateGood, result.State, result.Detail)
+	assert.Equal(t, "http://service.internal/status", proxied)
+	assert.True(t, strings.HasPrefix(remote, "127.0.0.1:"))
+}
+
+func TestClientOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options ClientOptions
+		error   string
+	}{
+		{"MissingKey", ClientOptions{ClientCertificate: "cert.pem"}, "must be specified together"},
+		{"MissingCaFile", ClientOptions{CaFile: "/does/not/exist"}, "unable to read ca_file"},
+		{"InvalidProxy", ClientOptions{Proxy: "proxy:3128"}, "invalid proxy"},
+		{"InvalidHttpVersion", ClientOptions{HttpVersion: "3"}, "invalid value for http_version"},
+		{"InvalidSourceAddress", ClientOptions{SourceAddress: "eth0"}, "invalid source_address"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.options.Validate()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), test.error)
+		})
+	}
+}
+
+func TestGetCheck_RecordsTimings(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	check := Plugin{}.Check("get").(GetCheck)
+	check.Url = strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	check.InsecureSkipVerify = true
+
+	result := check.Execute(context.Background())
+	assert.Equal(t, goplum.StateGood, result.State, result.Detail)
+	for _, fact := range []goplum.Fact{DnsTime, ConnectTime, TlsHandshakeTime, FirstByteTime, TotalTime, goplum.ResponseTime} {
+		assert.IsType(t, time.Duration(0), result.Facts[fact], "missing fact %s", fact)
+	}
+	assert.GreaterOrEqual(t, result.Facts[FirstByteTime], 20*time.Millisecond)
+	assert.GreaterOrEqual(t, result.Facts[TotalTime], result.Facts[FirstByteTime])
+	assert.Equal(t, result.Facts[TotalTime], result.Facts[goplum.ResponseTime])
+}
+
+func TestTimings_ConcurrentConnects(t *testing.T) {
+	timing := &timings{}
+	req := timing.trace(httptest.NewRequest(http.MethodGet, "/", nil))
+	trace := httptrace.ContextClientTrace(req.Context())
+
+	trace.ConnectStart("tcp", "[::1]:443")
+	time.Sleep(20 * time.Millisecond)
+	trace.ConnectStart("tcp", "127.0.0.1:443")
+	trace.ConnectDone("tcp", "[::1]:443", errors.New("connection refused"))
+	trace.ConnectDone("tcp", "127.0.0.1:443", nil)
+	trace.ConnectDone("tcp", "[::1]:443", nil)
+
+	facts := make(map[goplum.Fact]any)
+	timing.finish(facts)
+	assert.GreaterOrEqual(t, facts[ConnectTime], 20*time.Millisecond)
+	assert.LessOrEqual(t, facts[ConnectTime], facts[TotalTime])
+}
+
+func TestBaseCheck_CheckResponseTimeKeepsWorseStates(t *testing.T) {
+	base := BaseCheck{MaxResponseTime: 100 * time.Millisecond, WarningResponseTime: 50 * time.Millisecond}
+
+	tests := []struct {
+		name     string
+		result   goplum.Result
+		elapsed  time.Duration
+		expected goplum.CheckState
+		detail   string
+	}{
+		{"GoodToWarning", goplum.GoodResult(), 75 * time.Millisecond, goplum.StateWarning, "longer than the warning threshold"},
+		{"GoodToFailing", goplum.GoodResult(), 150 * time.Millisecond, goplum.StateFailing, "longer than the maximum"},
+		{"WarningUnchanged", goplum.WarningResult("degraded"), 75 * time.Millisecond, goplum.StateWarning, "degraded"},
+		{"WarningToFailing", goplum.WarningResult("degraded"), 150 * time.Millisecond, goplum.StateFailing, "longer than the maximum"},
+		{"FailingUnchanged", goplum.FailingResult("down"), 150 * time.Millisecond, goplum.StateFailing, "down"},
+		{"IndeterminateUnchanged", goplum.IndeterminateResult("unknown"), 150 * time.Millisecond, goplum.StateIndeterminate, "unknown"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := base.checkResponseTime(test.result, test.elapsed)
+			assert.Equal(t, test.expected, result.State)
+			assert.Contains(t, result.Detail, test.detail)
+		})
+	}
+}
+
+func TestBaseCheck_ResponseTimeThresholds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		if r.URL.Path == "/health" {
+			_, _ = w.Write([]byte(`{"status":"pass"}`))
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		check    string
+		max      time.Duration
+		warning  time.Duration
+		expected goplum.CheckState
+		detail   string
+	}{
+		{"GetWithinLimits", "get", time.Second, 500 * time.Millisecond, goplum.StateGood, ""},
+		{"GetWarning", "get", time.Second, 10 * time.Millisecond, goplum.StateWarning, "longer than the warning threshold of 10ms"},
+		{"GetFailing", "get", 10 * time.Millisecond, 5 * time.Millisecond, goplum.StateFailing, "longer than the maximum of 10ms"},
+		{"HealthWarning", "healthcheck", 0, 10 * time.Millisecond, goplum.StateWarning, "longer than the warning threshold of 10ms"},
+		{"HealthFailing", "healthcheck", 10 * time.Millisecond, 0, goplum.StateFailing, "longer than the maximum of 10ms"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var check interface {
+				goplum.Check
+				goplum.Validator
+			}
+			base := BaseCheck{Url: server.URL, MaxResponseTime: test.max, WarningResponseTime: test.warning}
+			if test.check == "get" {
+				get := Plugin{}.Check("get").(GetCheck)
+				get.BaseCheck = base
+				check = get
+			} else {
+				base.Url += "/health"
+				check = HealthCheck{BaseCheck: base}
+			}
+			require.NoError(t, check.Validate())
+
+			result := check.Execute(context.Background())
+			assert.Equal(t, test.expected, result.State)
+			assert.Contains(t, result.Detail, test.detail)
+			assert.Contains(t, result.Facts, goplum.ResponseTime)
+		})
+	}
+}
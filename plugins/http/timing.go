@@ -0,0 +1,113 @@
+package http
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"chameth.com/goplum"
+)
+
+var (
+	// DnsTime is the time spent resolving the server's hostname. Its value is a time.Duration.
+	DnsTime goplum.Fact = "chameth.com/goplum/plugins/http#dns_time"
+
+	// ConnectTime is the time spent establishing a connection to the server. Its value is a time.Duration.
+	ConnectTime goplum.Fact = "chameth.com/goplum/plugins/http#connect_time"
+
+	// TlsHandshakeTime is the time spent performing the TLS handshake. Its value is a time.Duration.
+	TlsHandshakeTime goplum.Fact = "chameth.com/goplum/plugins/http#tls_handshake_time"
+
+	// FirstByteTime is the time between starting the request and receiving the first byte of the response. Its
+	// value is a time.Duration.
+	FirstByteTime goplum.Fact = "chameth.com/goplum/plugins/http#first_byte_time"
+
+	// TotalTime is the time between starting the request and finishing reading the response. Its value is a
+	// time.Duration.
+	TotalTime goplum.Fact = "chameth.com/goplum/plugins/http#total_time"
+)
+
+// timings records how long each stage of a request takes. If a request is redirected, the time spent in each
+// stage is summed across all the requests made.
+type timings struct {
+	mu           sync.Mutex
+	start        time.Time
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+	dns          time.Duration
+	connect      time.Duration
+	tls          time.Duration
+	firstByte    time.Duration
+}
+
+// trace starts timing, and returns a copy of the request that will record the time taken by each stage.
+func (t *timings) trace(req *http.Request) *http.Request {
+	t.start = time.Now()
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), &httptrace.ClientTrace{
+		DNSStart:     func(httptrace.DNSStartInfo) { t.begin(&t.dnsStart) },
+		DNSDone:      func(httptrace.DNSDoneInfo) { t.end(&t.dns, &t.dnsStart) },
+		ConnectStart: func(string, string) { t.begin(&t.connectStart) },
+		ConnectDone: func(_, _ string, err error) {
+			// When dialling multiple addresses (e.g. both IPv4 and IPv6), only the first connection to succeed is
+			// used, so the connect time runs from the first attempt to the first success.
+			if err == nil {
+				t.end(&t.connect, &t.connectStart)
+			}
+		},
+		TLSHandshakeStart: func() { t.begin(&t.tlsStart) },
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { t.end(&t.tls, &t.tlsStart) },
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.firstByte = time.Since(t.start)
+		},
+	}))
+}
+
+// begin marks the start of a stage, unless it has already started.
+func (t *timings) begin(start *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if start.IsZero() {
+		*start = time.Now()
+	}
+}
+
+// end adds the time since the stage started to the total, if it is in progress, and resets it so it can be timed
+// again on a later request.
+func (t *timings) end(total *time.Duration, start *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !start.IsZero() {
+		*total += time.Since(*start)
+		*start = time.Time{}
+	}
+}
+
+// finish stops timing, and records the duration of each stage that occurred in the facts. The total time is
+// also recorded as the response time, and returned.
+func (t *timings) finish(facts map[goplum.Fact]any) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	total := time.Since(t.start)
+	facts[TotalTime] = total
+	facts[goplum.ResponseTime] = total
+
+	stages := map[goplum.Fact]time.Duration{
+		DnsTime:          t.dns,
+		ConnectTime:      t.connect,
+		TlsHandshakeTime: t.tls,
+		FirstByteTime:    t.firstByte,
+	}
+	for fact, duration := range stages {
+		if duration > 0 {
+			facts[fact] = duration
+		}
+	}
+
+	return total
+}
@@ -0,0 +1,126 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// HTTP versions that checks can be restricted to.
+const (
+	httpVersion1 = "1.1"
+	httpVersion2 = "2"
+)
+
+// ClientOptions control how checks connect to remote servers.
+type ClientOptions struct {
+	CaFile             string `config:"ca_file"`
+	ClientCertificate  string `config:"client_certificate"`
+	ClientKey          string `config:"client_key"`
+	InsecureSkipVerify bool   `config:"insecure_skip_verify"`
+	Proxy              string
+	HttpVersion        string `config:"http_version"`
+	SourceAddress      string `config:"source_address"`
+}
+
+// customised determines whether any options have been set, and so the default transport can't be used.
+func (o ClientOptions) customised() bool {
+	return o != ClientOptions{}
+}
+
+// transport creates a new HTTP transport with the configured options. Certificates are loaded from disk each
+// time, so renewed certificates will be picked up without needing to reload the config.
+func (o ClientOptions) transport() (*http.Transport, error) {
+	transport := client.Transport.(*http.Transport).Clone()
+
+	tlsConfig, err := o.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	if len(o.Proxy) > 0 {
+		proxy, err := url.Parse(o.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy: %v", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	switch o.HttpVersion {
+	case httpVersion1:
+		transport.Protocols = new(http.Protocols)
+		transport.Protocols.SetHTTP1(true)
+	case httpVersion2:
+		transport.Protocols = new(http.Protocols)
+		transport.Protocols.SetHTTP2(true)
+		transport.Protocols.SetUnencryptedHTTP2(true)
+	}
+
+	if len(o.SourceAddress) > 0 {
+		dialer := &net.Dialer{LocalAddr: &net.TCPAddr{IP: net.ParseIP(o.SourceAddress)}}
+		transport.DialContext = dialer.DialContext
+	}
+
+	return transport, nil
+}
+
+func (o ClientOptions) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: o.InsecureSkipVerify,
+	}
+
+	if len(o.CaFile) > 0 {
+		b, err := os.ReadFile(o.CaFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read ca_file: %v", err)
+		}
+
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("no certificates found in ca_file %s", o.CaFile)
+		}
+	}
+
+	if len(o.ClientCertificate) > 0 {
+		cert, err := tls.LoadX509KeyPair(o.ClientCertificate, o.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %v", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+func (o ClientOptions) Validate() error {
+	if (len(o.ClientCertificate) > 0) != (len(o.ClientKey) > 0) {
+		return fmt.Errorf("client_certificate and client_key must be specified together")
+	}
+
+	if _, err := o.tlsConfig(); err != nil {
+		return err
+	}
+
+	if len(o.Proxy) > 0 {
+		if u, err := url.Parse(o.Proxy); err != nil || len(u.Scheme) == 0 || len(u.Host) == 0 {
+			return fmt.Errorf("invalid proxy %q, expected a URL such as http://proxy:3128", o.Proxy)
+		}
+	}
+
+	switch o.HttpVersion {
+	case "", httpVersion1, httpVersion2:
+	default:
+		return fmt.Errorf("invalid value for http_version: %q, expected %q or %q", o.HttpVersion, httpVersion1, httpVersion2)
+	}
+
+	if len(o.SourceAddress) > 0 && net.ParseIP(o.SourceAddress) == nil {
+		return fmt.Errorf("invalid source_address %q, expected an IP address", o.SourceAddress)
+	}
+
+	return nil
+}
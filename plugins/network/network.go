@@ -1,9 +1,13 @@
 package network
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net"
+	"regexp"
 	"slices"
 	"strconv"
 	"sync"
@@ -25,7 +29,16 @@ func (p Plugin) Check(kind string) goplum.Check {
 	switch kind {
 	case "connect":
 		return ConnectCheck{
-			Network: "tcp",
+			Network:     "tcp",
+			ReadTimeout: 5 * time.Second,
+		}
+	case "ping":
+		return PingCheck{
+			Network:      "ip",
+			Count:        5,
+			Interval:     time.Second,
+			ReplyTimeout: time.Second,
+			MaxLoss:      50,
 		}
 	case "portscan":
 		return PortScanCheck{
@@ -42,19 +55,102 @@ func (p Plugin) Check(kind string) goplum.Check {
 	}
 }
 
+// maxResponseSize is the maximum number of bytes that will be read from a connection when checking responses.
+const maxResponseSize = 64 * 1024
+
 type ConnectCheck struct {
-	Network string
-	Address string
+	Network     string
+	Address     string
+	Send        string
+	Expect      string
+	ExpectRegex string        `config:"expect_regex"`
+	ReadTimeout time.Duration `config:"read_timeout"`
 }
 
 func (c ConnectCheck) Execute(ctx context.Context) goplum.Result {
+	start := time.Now()
 	d := net.Dialer{}
 	conn, err := d.DialContext(ctx, c.Network, c.Address)
 	if err != nil {
 		return goplum.FailingResult("unable to connect to %s: %v", c.Address, err)
 	}
 	defer conn.Close()
-	return goplum.GoodResult()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if len(c.Send) > 0 {
+		if _, err := conn.Write([]byte(c.Send)); err != nil {
+			return goplum.FailingResult("unable to send to %s: %v", c.Address, err)
+		}
+	}
+
+	if len(c.Expect) == 0 && len(c.ExpectRegex) == 0 {
+		return goplum.GoodResult()
+	}
+
+	response, err := c.read(ctx, conn)
+	if err != nil {
+		return goplum.FailingResult("unable to read from %s: %v", c.Address, err)
+	}
+
+	var result goplum.Result
+	if len(c.Expect) > 0 && !bytes.Contains(response, []byte(c.Expect)) {
+		result = goplum.FailingResult("response from %s did not contain '%s' (got %s)", c.Address, c.Expect, summarise(response))
+	} else if len(c.ExpectRegex) > 0 && !regexp.MustCompile(c.ExpectRegex).Match(response) {
+		result = goplum.FailingResult("response from %s did not match '%s' (got %s)", c.Address, c.ExpectRegex, summarise(response))
+	} else {
+		result = goplum.GoodResult()
+		result.Facts = map[goplum.Fact]any{goplum.ResponseTime: time.Since(start)}
+	}
+	return result
+}
+
+// read reads from the connection until the response meets the check's expectations, the connection is closed,
+// or the read timeout elapses. Whatever was read is returned.
+func (c ConnectCheck) read(ctx context.Context, conn net.Conn) ([]byte, error) {
+	deadline := time.Now().Add(c.ReadTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	_ = conn.SetReadDeadline(deadline)
+
+	var re *regexp.Regexp
+	if len(c.ExpectRegex) > 0 {
+		re = regexp.MustCompile(c.ExpectRegex)
+	}
+
+	var response []byte
+	buf := make([]byte, 4096)
+	for len(response) < maxResponseSize {
+		n, err := conn.Read(buf)
+		response = append(response, buf[:n]...)
+
+		if bytes.Contains(response, []byte(c.Expect)) && (re == nil || re.Match(response)) {
+			return response, nil
+		}
+
+		if err != nil {
+			// Timeouts and closed connections are reported as an unexpected response, as we may still have
+			// received something useful.
+			if ne, ok := err.(net.Error); (ok && ne.Timeout()) || errors.Is(err, io.EOF) {
+				return response, nil
+			}
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+// summarise formats the start of a response for use in a result's detail.
+func summarise(response []byte) string {
+	const limit = 100
+	if len(response) > limit {
+		return fmt.Sprintf("%q...", response[:limit])
+	}
+	return fmt.Sprintf("%q", response)
 }
 
 func (c ConnectCheck) Validate() error {
@@ -67,6 +163,16 @@ func (c ConnectCheck) Validate() error {
 		return err
 	}
 
+	if len(c.ExpectRegex) > 0 {
+		if _, err := regexp.Compile(c.ExpectRegex); err != nil {
+			return fmt.Errorf("invalid expect_regex: %v", err)
+		}
+	}
+
+	if (len(c.Expect) > 0 || len(c.ExpectRegex) > 0) && c.ReadTimeout <= 0 {
+		return fmt.Errorf("invalid read_timeout: must be greater than zero")
+	}
+
 	return nil
 }
 
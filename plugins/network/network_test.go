@@ -0,0 +1,177 @@
+package network
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"chameth.com/goplum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveTcp starts a TCP server that handles each connection with the given function, and returns its address.
+func serveTcp(t *testing.T, handler func(conn net.Conn)) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				handler(conn)
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+// serveUdp starts a UDP server that replies to each packet with the result of the given function, and returns
+// its address.
+func serveUdp(t *testing.T, reply func(request string) string) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			_, _ = conn.WriteTo([]byte(reply(string(buf[:n]))), addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+// redis responds to PING commands in the same way as a Redis server.
+func redis(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		if strings.TrimSpace(line) == "PING" {
+			_, _ = conn.Write([]byte("+PONG\r\n"))
+		} else {
+			_, _ = conn.Write([]byte("-ERR unknown command\r\n"))
+		}
+	}
+}
+
+// banner sends an SMTP greeting and then waits for the client to disconnect.
+func banner(conn net.Conn) {
+	_, _ = conn.Write([]byte("220 mail.example.com ESMTP\r\n"))
+	_, _ = conn.Read(make([]byte, 1))
+}
+
+// silent accepts connections but never sends anything.
+func silent(conn net.Conn) {
+	_, _ = conn.Read(make([]byte, 1))
+}
+
+func TestConnectCheck_Execute(t *testing.T) {
+	tests := []struct {
+		name        string
+		handler     func(conn net.Conn)
+		send        string
+		expect      string
+		expectRegex string
+		expected    goplum.CheckState
+		detail      string
+	}{
+		{"ConnectOnly", silent, "", "", "", goplum.StateGood, ""},
+		{"Redis", redis, "PING\r\n", "+PONG", "", goplum.StateGood, ""},
+		{"RedisWrongCommand", redis, "PONG\r\n", "+PONG", "", goplum.StateFailing, "did not contain '+PONG' (got \"-ERR unknown command\\r\\n\")"},
+		{"Banner", banner, "", "", "^220 .*ESMTP", goplum.StateGood, ""},
+		{"BannerMismatch", banner, "", "", "^220 .*LMTP", goplum.StateFailing, "did not match '^220 .*LMTP'"},
+		{"BothExpectations", banner, "", "ESMTP", "^220 ", goplum.StateGood, ""},
+		{"NoResponse", silent, "", "220", "", goplum.StateFailing, "did not contain '220' (got \"\")"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check := Plugin{}.Check("connect").(ConnectCheck)
+			check.Address = serveTcp(t, test.handler)
+			check.Send = test.send
+			check.Expect = test.expect
+			check.ExpectRegex = test.expectRegex
+			check.ReadTimeout = 100 * time.Millisecond
+			require.NoError(t, check.Validate())
+
+			result := check.Execute(context.Background())
+			assert.Equal(t, test.expected, result.State, result.Detail)
+			assert.Contains(t, result.Detail, test.detail)
+			if test.expected == goplum.StateGood && (len(test.expect) > 0 || len(test.expectRegex) > 0) {
+				assert.NotZero(t, result.Facts[goplum.ResponseTime])
+			}
+		})
+	}
+}
+
+func TestConnectCheck_Udp(t *testing.T) {
+	address := serveUdp(t, func(request string) string {
+		return "echo: " + request
+	})
+
+	tests := []struct {
+		name     string
+		send     string
+		expect   string
+		expected goplum.CheckState
+	}{
+		{"Match", "hello", "echo: hello", goplum.StateGood},
+		{"Mismatch", "hello", "echo: goodbye", goplum.StateFailing},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check := Plugin{}.Check("connect").(ConnectCheck)
+			check.Network = "udp"
+			check.Address = address
+			check.Send = test.send
+			check.Expect = test.expect
+			check.ReadTimeout = 100 * time.Millisecond
+			require.NoError(t, check.Validate())
+
+			result := check.Execute(context.Background())
+			assert.Equal(t, test.expected, result.State, result.Detail)
+		})
+	}
+}
+
+func TestConnectCheck_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		check ConnectCheck
+		error string
+	}{
+		{"MissingAddress", ConnectCheck{}, "missing required argument: address"},
+		{"MissingPort", ConnectCheck{Address: "example.com"}, "missing port"},
+		{"InvalidRegex", ConnectCheck{Address: "example.com:25", ExpectRegex: "("}, "invalid expect_regex"},
+		{"MissingReadTimeout", ConnectCheck{Address: "example.com:25", Expect: "220"}, "invalid read_timeout"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.check.Validate()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), test.error)
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"slices"
+	"time"
+
+	"chameth.com/goplum"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+var (
+	// MinRtt is the shortest round-trip time of any ping. Its value is a time.Duration.
+	MinRtt goplum.Fact = "chameth.com/goplum/plugins/network#min_rtt"
+
+	// AvgRtt is the mean round-trip time of all pings that received a reply. Its value is a time.Duration.
+	AvgRtt goplum.Fact = "chameth.com/goplum/plugins/network#avg_rtt"
+
+	// MaxRtt is the longest round-trip time of any ping. Its value is a time.Duration.
+	MaxRtt goplum.Fact = "chameth.com/goplum/plugins/network#max_rtt"
+
+	// PacketLoss is the percentage of pings that didn't receive a reply. Its value is a float64.
+	PacketLoss goplum.Fact = "chameth.com/goplum/plugins/network#packet_loss"
+)
+
+// errInterrupted is returned when the context is done before a reply has been received, in which case we can't tell
+// if the ping was lost.
+var errInterrupted = errors.New("interrupted waiting for reply")
+
+// ICMP protocol numbers, as used when parsing messages.
+const (
+	protocolIcmp   = 1
+	protocolIcmpV6 = 58
+)
+
+type PingCheck struct {
+	Address      string
+	Network      string
+	Count        int
+	Interval     time.Duration
+	ReplyTimeout time.Duration `config:"reply_timeout"`
+	Privileged   bool
+	MaxLoss      float64       `config:"max_loss"`
+	MaxRtt       time.Duration `config:"max_rtt"`
+}
+
+func (c PingCheck) Timeout() time.Duration {
+	return time.Duration(c.Count)*max(c.Interval, c.ReplyTimeout) + time.Second
+}
+
+func (c PingCheck) Execute(ctx context.Context) goplum.Result {
+	addr, err := net.DefaultResolver.LookupIP(ctx, c.Network, c.Address)
+	if err != nil {
+		return goplum.FailingResult("unable to resolve %s: %v", c.Address, err)
+	}
+
+	sent, rtts, err := c.ping(ctx, addr[0])
+	if err != nil {
+		return goplum.FailingResult("unable to ping %s: %v", c.Address, err)
+	}
+
+	if sent == 0 {
+		return goplum.FailingResult("timed out before any pings to %s completed", c.Address)
+	}
+
+	facts := map[goplum.Fact]any{
+		PacketLoss: 100 * float64(sent-len(rtts)) / float64(sent),
+	}
+
+	if len(rtts) == 0 {
+		result := goplum.FailingResult("no replies received from %s (%d sent)", c.Address, sent)
+		result.Facts = facts
+		return result
+	}
+
+	var total time.Duration
+	for _, rtt := range rtts {
+		total += rtt
+	}
+	facts[MinRtt] = slices.Min(rtts)
+	facts[MaxRtt] = slices.Max(rtts)
+	facts[AvgRtt] = total / time.Duration(len(rtts))
+	facts[goplum.ResponseTime] = facts[AvgRtt]
+
+	var result goplum.Result
+	if loss := facts[PacketLoss].(float64); loss > c.MaxLoss {
+		result = goplum.FailingResult("packet loss of %.0f%% exceeds maximum of %.0f%%", loss, c.MaxLoss)
+	} else if avg := facts[AvgRtt].(time.Duration); c.MaxRtt > 0 && avg > c.MaxRtt {
+		result = goplum.FailingResult("average round-trip time of %s exceeds maximum of %s", avg.Round(time.Microsecond), c.MaxRtt)
+	} else {
+		result = goplum.GoodResult()
+	}
+	result.Facts = facts
+	return result
+}
+
+// ping sends echo requests to the given address, and returns the number of requests that either received a reply
+// or timed out waiting for one, along with the round-trip times of those that received replies. Requests that were
+// cut short by the context being done aren't counted.
+func (c PingCheck) ping(ctx context.Context, ip net.IP) (int, []time.Duration, error) {
+	network, listen, protocol := c.socket(ip)
+	conn, err := icmp.ListenPacket(network, listen)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer conn.Close()
+
+	var dst net.Addr = &net.IPAddr{IP: ip}
+	if !c.Privileged {
+		dst = &net.UDPAddr{IP: ip}
+	}
+
+	var requestType icmp.Type = ipv4.ICMPTypeEcho
+	if protocol == protocolIcmpV6 {
+		requestType = ipv6.ICMPTypeEchoRequest
+	}
+
+	// Unprivileged sockets have their ID rewritten by the kernel, and only receive replies meant for them.
+	// Privileged sockets receive all ICMP traffic, so we need to filter replies by ID.
+	id := rand.IntN(1 << 16)
+	next := time.Now()
+	var sent int
+	var rtts []time.Duration
+	for seq := range c.Count {
+		select {
+		case <-ctx.Done():
+			return sent, rtts, nil
+		case <-time.After(time.Until(next)):
+		}
+
+		next = time.Now().Add(c.Interval)
+		rtt, err := c.echo(ctx, conn, dst, protocol, requestType, id, seq)
+		if errors.Is(err, errInterrupted) {
+			return sent, rtts, nil
+		} else if err != nil {
+			return 0, nil, err
+		}
+
+		sent++
+		if rtt >= 0 {
+			rtts = append(rtts, rtt)
+		}
+	}
+
+	return sent, rtts, nil
+}
+
+// echo sends a single echo request and waits for the reply. If no reply is received within the timeout, a
+// negative duration is returned. If the context is done before then, errInterrupted is returned.
+func (c PingCheck) echo(ctx context.Context, conn *icmp.PacketConn, dst net.Addr, protocol int, requestType icmp.Type, id, seq int) (time.Duration, error) {
+	request := icmp.Message{
+		Type: requestType,
+		Body: &icmp.Echo{ID: id, Seq: seq, Data: []byte("goplum")},
+	}
+
+	b, err := request.Marshal(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+	if _, err := conn.WriteTo(b, dst); err != nil {
+		return 0, err
+	}
+
+	deadline := start.Add(c.ReplyTimeout)
+	interrupted := false
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+		interrupted = true
+	}
+	_ = conn.SetReadDeadline(deadline)
+
+	buf := make([]byte, 1500)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				if interrupted || ctx.Err() != nil {
+					return 0, errInterrupted
+				}
+				return -1, nil
+			}
+			return 0, err
+		}
+
+		reply, err := icmp.ParseMessage(protocol, buf[:n])
+		if err != nil {
+			continue
+		}
+
+		echo, ok := reply.Body.(*icmp.Echo)
+		if !ok || (reply.Type != ipv4.ICMPTypeEchoReply && reply.Type != ipv6.ICMPTypeEchoReply) {
+			continue
+		}
+
+		if echo.Seq == seq && (!c.Privileged || echo.ID == id) {
+			return time.Since(start), nil
+		}
+	}
+}
+
+// socket returns the network and address to listen on, and the ICMP protocol number, for pinging the given IP.
+func (c PingCheck) socket(ip net.IP) (string, string, int) {
+	switch {
+	case ip.To4() != nil && c.Privileged:
+		return "ip4:icmp", "0.0.0.0", protocolIcmp
+	case ip.To4() != nil:
+		return "udp4", "0.0.0.0", protocolIcmp
+	case c.Privileged:
+		return "ip6:ipv6-icmp", "::", protocolIcmpV6
+	default:
+		return "udp6", "::", protocolIcmpV6
+	}
+}
+
+func (c PingCheck) Validate() error {
+	if len(c.Address) == 0 {
+		return fmt.Errorf("missing required argument: address")
+	}
+
+	switch c.Network {
+	case "ip", "ip4", "ip6":
+	default:
+		return fmt.Errorf("invalid network %q, expected \"ip\", \"ip4\" or \"ip6\"", c.Network)
+	}
+
+	if c.Count < 1 {
+		return fmt.Errorf("invalid count: must be at least 1")
+	}
+
+	if c.Interval <= 0 {
+		return fmt.Errorf("invalid interval: must be greater than zero")
+	}
+
+	if c.ReplyTimeout <= 0 {
+		return fmt.Errorf("invalid reply_timeout: must be greater than zero")
+	}
+
+	if c.MaxLoss < 0 || c.MaxLoss > 100 {
+		return fmt.Errorf("invalid max_loss: must be a percentage between 0 and 100")
+	}
+
+	return nil
+}
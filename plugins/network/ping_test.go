@@ -0,0 +1,106 @@
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"chameth.com/goplum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/icmp"
+)
+
+// requireSocket skips the test if the ICMP socket needed for the check can't be opened, e.g. because the test
+// isn't running with the required privileges.
+func requireSocket(t *testing.T, check PingCheck) {
+	network, address, _ := check.socket([]byte{127, 0, 0, 1})
+	conn, err := icmp.ListenPacket(network, address)
+	if err != nil {
+		t.Skipf("unable to open %s socket: %v", network, err)
+	}
+	_ = conn.Close()
+}
+
+func TestPingCheck_Execute(t *testing.T) {
+	tests := []struct {
+		name       string
+		privileged bool
+		maxRtt     time.Duration
+		expected   goplum.CheckState
+		detail     string
+	}{
+		{"Unprivileged", false, 0, goplum.StateGood, ""},
+		{"Privileged", true, 0, goplum.StateGood, ""},
+		{"MaxRtt", true, time.Nanosecond, goplum.StateFailing, "average round-trip time of"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check := Plugin{}.Check("ping").(PingCheck)
+			check.Address = "127.0.0.1"
+			check.Count = 3
+			check.Interval = 10 * time.Millisecond
+			check.Privileged = test.privileged
+			check.MaxRtt = test.maxRtt
+			require.NoError(t, check.Validate())
+			requireSocket(t, check)
+
+			ctx, cancel := context.WithTimeout(context.Background(), check.Timeout())
+			defer cancel()
+
+			result := check.Execute(ctx)
+			assert.Equal(t, test.expected, result.State)
+			assert.Contains(t, result.Detail, test.detail)
+			assert.Equal(t, 0.0, result.Facts[PacketLoss])
+			assert.LessOrEqual(t, result.Facts[MinRtt], result.Facts[AvgRtt])
+			assert.LessOrEqual(t, result.Facts[AvgRtt], result.Facts[MaxRtt])
+		})
+	}
+}
+
+func TestPingCheck_InterruptedPingsAreNotLost(t *testing.T) {
+	check := Plugin{}.Check("ping").(PingCheck)
+	check.Address = "127.0.0.1"
+	check.Count = 5
+	check.Interval = 200 * time.Millisecond
+	check.Privileged = true
+	require.NoError(t, check.Validate())
+	requireSocket(t, check)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	result := check.Execute(ctx)
+	assert.Equal(t, goplum.StateGood, result.State, result.Detail)
+	assert.Equal(t, 0.0, result.Facts[PacketLoss])
+}
+
+func TestPingCheck_Validate(t *testing.T) {
+	valid := Plugin{}.Check("ping").(PingCheck)
+	valid.Address = "192.0.2.1"
+	assert.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(*PingCheck)
+		error  string
+	}{
+		{"MissingAddress", func(c *PingCheck) { c.Address = "" }, "missing required argument: address"},
+		{"InvalidNetwork", func(c *PingCheck) { c.Network = "tcp" }, "invalid network"},
+		{"InvalidCount", func(c *PingCheck) { c.Count = 0 }, "invalid count"},
+		{"InvalidInterval", func(c *PingCheck) { c.Interval = 0 }, "invalid interval"},
+		{"InvalidReplyTimeout", func(c *PingCheck) { c.ReplyTimeout = 0 }, "invalid reply_timeout"},
+		{"InvalidMaxLoss", func(c *PingCheck) { c.MaxLoss = 101 }, "invalid max_loss"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check := valid
+			test.modify(&check)
+			err := check.Validate()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), test.error)
+		})
+	}
+}
@@ -41,6 +41,7 @@ type MessageAlert struct {
 	Key        string
 	Devices    []string
 	Failing    PushSettings
+	Warning    PushSettings
 	Recovering PushSettings
 	errored    bool
 }
@@ -53,6 +54,8 @@ func (m MessageAlert) Send(details goplum.AlertDetails) error {
 	var settings PushSettings
 	if details.NewState == goplum.StateFailing {
 		settings = m.Failing
+	} else if details.NewState == goplum.StateWarning {
+		settings = m.Warning
 	} else {
 		settings = m.Recovering
 	}
@@ -118,6 +121,9 @@ func (m MessageAlert) Validate() error {
 	if err := m.validateSettings(m.Failing); err != nil {
 		return fmt.Errorf("failing block invalid: %v", err)
 	}
+	if err := m.validateSettings(m.Warning); err != nil {
+		return fmt.Errorf("warning block invalid: %v", err)
+	}
 	if err := m.validateSettings(m.Recovering); err != nil {
 		return fmt.Errorf("recovering block invalid: %v", err)
 	}
@@ -0,0 +1,388 @@
+package tls
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"chameth.com/goplum"
+)
+
+var (
+	// Issuer is the distinguished name of the issuer of the server's certificate. Its value is a string.
+	Issuer goplum.Fact = "chameth.com/goplum/plugins/tls#issuer"
+
+	// Subject is the distinguished name of the subject of the server's certificate. Its value is a string.
+	Subject goplum.Fact = "chameth.com/goplum/plugins/tls#subject"
+
+	// SubjectAlternativeNames is a comma-separated list of the DNS names and IP addresses the server's
+	// certificate is valid for. Its value is a string.
+	SubjectAlternativeNames goplum.Fact = "chameth.com/goplum/plugins/tls#sans"
+
+	// NotAfter is the time the server's certificate expires, in RFC 3339 format. Its value is a string.
+	NotAfter goplum.Fact = "chameth.com/goplum/plugins/tls#not_after"
+
+	// ExpiresIn is the length of time until the first certificate in the chain expires. Its value should be a
+	// time.Duration.
+	ExpiresIn goplum.Fact = "chameth.com/goplum/plugins/tls#expires_in"
+)
+
+// Protocols that can be used to upgrade a plain-text connection to TLS.
+const (
+	startTlsSmtp = "smtp"
+	startTlsImap = "imap"
+	startTlsLdap = "ldap"
+)
+
+type Plugin struct{}
+
+func (p Plugin) Alert(_ string) goplum.Alert {
+	return nil
+}
+
+func (p Plugin) Check(kind string) goplum.Check {
+	switch kind {
+	case "certificate":
+		return CertificateCheck{
+			WarningValidity: 14 * 24 * time.Hour,
+		}
+	default:
+		return nil
+	}
+}
+
+type CertificateCheck struct {
+	Address             string
+	ServerName          string        `config:"server_name"`
+	StartTls            string        `config:"starttls"`
+	CaFile              string        `config:"ca_file"`
+	CertificateValidity time.Duration `config:"certificate_validity"`
+	WarningValidity     time.Duration `config:"warning_validity"`
+}
+
+func (c CertificateCheck) Execute(ctx context.Context) goplum.Result {
+	certs, err := c.handshake(ctx)
+	if err != nil {
+		return goplum.FailingResult("TLS handshake with %s failed: %v", c.Address, err)
+	}
+
+	leaf := certs[0]
+	facts := map[goplum.Fact]any{
+		Issuer:                  leaf.Issuer.String(),
+		Subject:                 leaf.Subject.String(),
+		SubjectAlternativeNames: sans(leaf),
+		NotAfter:                leaf.NotAfter.Format(time.RFC3339),
+	}
+
+	roots, err := c.roots()
+	if err != nil {
+		return goplum.FailingResult("Unable to load CA file: %v", err)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	chains, err := leaf.Verify(x509.VerifyOptions{
+		DNSName:       c.serverName(),
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+	if err != nil {
+		result := goplum.FailingResult("Certificate verification failed: %v", err)
+		result.Facts = facts
+		return result
+	}
+
+	// The server may send extra certificates that aren't part of the chain we verified, so check the expiry of
+	// the verified chain (including the root) rather than what was sent.
+	expiring := chains[0][0]
+	for _, cert := range chains[0][1:] {
+		if cert.NotAfter.Before(expiring.NotAfter) {
+			expiring = cert
+		}
+	}
+
+	remaining := time.Until(expiring.NotAfter)
+	facts[ExpiresIn] = remaining
+
+	var result goplum.Result
+	if remaining < c.CertificateValidity {
+		result = goplum.FailingResult("Certificate '%s' expires in %s", expiring.Subject, remaining.Round(time.Minute))
+	} else if remaining < c.WarningValidity {
+		result = goplum.WarningResult("Certificate '%s' expires in %s", expiring.Subject, remaining.Round(time.Minute))
+	} else {
+		result = goplum.GoodResult()
+	}
+	result.Facts = facts
+	return result
+}
+
+// handshake connects to the server, upgrades the connection using STARTTLS if required, and performs a TLS
+// handshake. The certificates presented by the server are returned without being verified.
+func (c CertificateCheck) handshake(ctx context.Context) ([]*x509.Certificate, error) {
+	d := net.Dialer{}
+	conn, err := d.DialContext(ctx, "tcp", c.Address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	config := &tls.Config{
+		ServerName: c.serverName(),
+		// Verification is done separately, so that we can report details of the certificates even if they
+		// aren't trusted.
+		InsecureSkipVerify: true,
+	}
+
+	var state tls.ConnectionState
+	switch c.StartTls {
+	case startTlsSmtp:
+		state, err = c.startTlsSmtp(ctx, conn, config)
+	case startTlsImap:
+		state, err = c.startTlsImap(ctx, conn, config)
+	case startTlsLdap:
+		state, err = c.startTlsLdap(ctx, conn, config)
+	default:
+		state, err = c.upgrade(ctx, conn, config)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(state.PeerCertificates) == 0 {
+		return nil, fmt.Errorf("server did not send a certificate")
+	}
+
+	return state.PeerCertificates, nil
+}
+
+// upgrade performs a TLS handshake over the given connection.
+func (c CertificateCheck) upgrade(ctx context.Context, conn net.Conn, config *tls.Config) (tls.ConnectionState, error) {
+	client := tls.Client(conn, config)
+	if err := client.HandshakeContext(ctx); err != nil {
+		return tls.ConnectionState{}, err
+	}
+	return client.ConnectionState(), nil
+}
+
+func (c CertificateCheck) startTlsSmtp(ctx context.Context, conn net.Conn, config *tls.Config) (tls.ConnectionState, error) {
+	text := textproto.NewConn(conn)
+
+	if _, _, err := text.ReadResponse(220); err != nil {
+		return tls.ConnectionState{}, err
+	}
+
+	if err := text.PrintfLine("EHLO localhost"); err != nil {
+		return tls.ConnectionState{}, err
+	}
+
+	_, extensions, err := text.ReadResponse(250)
+	if err != nil {
+		return tls.ConnectionState{}, err
+	}
+
+	if !slices.Contains(strings.Split(strings.ToUpper(extensions), "\n"), "STARTTLS") {
+		return tls.ConnectionState{}, fmt.Errorf("server does not support STARTTLS")
+	}
+
+	if err := text.PrintfLine("STARTTLS"); err != nil {
+		return tls.ConnectionState{}, err
+	}
+
+	if _, _, err := text.ReadResponse(220); err != nil {
+		return tls.ConnectionState{}, err
+	}
+
+	return c.upgrade(ctx, conn, config)
+}
+
+func (c CertificateCheck) startTlsImap(ctx context.Context, conn net.Conn, config *tls.Config) (tls.ConnectionState, error) {
+	text := textproto.NewConn(conn)
+
+	greeting, err := text.ReadLine()
+	if err != nil {
+		return tls.ConnectionState{}, err
+	}
+
+	if !strings.HasPrefix(greeting, "* OK") {
+		return tls.ConnectionState{}, fmt.Errorf("unexpected greeting from server: %s", greeting)
+	}
+
+	if err := text.PrintfLine("a1 STARTTLS"); err != nil {
+		return tls.ConnectionState{}, err
+	}
+
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return tls.ConnectionState{}, err
+		}
+
+		if strings.HasPrefix(line, "a1 ") {
+			if !strings.HasPrefix(line, "a1 OK") {
+				return tls.ConnectionState{}, fmt.Errorf("server refused STARTTLS: %s", line)
+			}
+			break
+		}
+	}
+
+	return c.upgrade(ctx, conn, config)
+}
+
+// ldapStartTlsRequest is a BER-encoded LDAP extended request for the StartTLS operation (OID 1.3.6.1.4.1.1466.20037).
+var ldapStartTlsRequest = append(
+	[]byte{0x30, 0x1d, 0x02, 0x01, 0x01, 0x77, 0x18, 0x80, 0x16},
+	"1.3.6.1.4.1.1466.20037"...,
+)
+
+func (c CertificateCheck) startTlsLdap(ctx context.Context, conn net.Conn, config *tls.Config) (tls.ConnectionState, error) {
+	if _, err := conn.Write(ldapStartTlsRequest); err != nil {
+		return tls.ConnectionState{}, err
+	}
+
+	// The response is an LDAPMessage sequence containing the message ID and an ExtendedResponse, which in turn
+	// starts with the result code.
+	reader := bufio.NewReader(conn)
+	_, message, err := readBer(reader)
+	if err != nil {
+		return tls.ConnectionState{}, fmt.Errorf("invalid response from server: %v", err)
+	}
+
+	body := bufio.NewReader(bytes.NewReader(message))
+	if _, _, err := readBer(body); err != nil {
+		return tls.ConnectionState{}, fmt.Errorf("invalid response from server: %v", err)
+	}
+
+	tag, response, err := readBer(body)
+	if err != nil || tag != 0x78 || len(response) < 3 || response[0] != 0x0a || response[1] != 0x01 {
+		return tls.ConnectionState{}, fmt.Errorf("invalid response from server")
+	}
+
+	if response[2] != 0 {
+		return tls.ConnectionState{}, fmt.Errorf("server refused StartTLS with result code %d", response[2])
+	}
+
+	return c.upgrade(ctx, conn, config)
+}
+
+// maxBerSize is the largest BER element we'll read from a server. LDAP responses to a StartTLS request are only a
+// few bytes long, so this guards against allocating huge buffers for hostile or broken servers.
+const maxBerSize = 64 * 1024
+
+// readBer reads a single BER-encoded element, returning its tag and contents.
+func readBer(r *bufio.Reader) (byte, []byte, error) {
+	tag, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	length, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	size := int(length)
+	if length&0x80 != 0 {
+		n := int(length & 0x7f)
+		if n == 0 || n > 4 {
+			return 0, nil, fmt.Errorf("unsupported length encoding")
+		}
+
+		size = 0
+		for range n {
+			b, err := r.ReadByte()
+			if err != nil {
+				return 0, nil, err
+			}
+			size = size<<8 | int(b)
+		}
+	}
+
+	if size > maxBerSize {
+		return 0, nil, fmt.Errorf("element too large: %d bytes", size)
+	}
+
+	content := make([]byte, size)
+	if _, err := io.ReadFull(r, content); err != nil {
+		return 0, nil, err
+	}
+	return tag, content, nil
+}
+
+// roots returns the pool of root certificates to trust, or nil to use the system pool.
+func (c CertificateCheck) roots() (*x509.CertPool, error) {
+	if len(c.CaFile) == 0 {
+		return nil, nil
+	}
+
+	b, err := os.ReadFile(c.CaFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(b) {
+		return nil, fmt.Errorf("no certificates found in %s", c.CaFile)
+	}
+	return pool, nil
+}
+
+// serverName returns the hostname the certificate is expected to be valid for.
+func (c CertificateCheck) serverName() string {
+	if len(c.ServerName) > 0 {
+		return c.ServerName
+	}
+
+	host, _, _ := net.SplitHostPort(c.Address)
+	return host
+}
+
+// sans returns a comma-separated list of the DNS names and IP addresses in the certificate.
+func sans(cert *x509.Certificate) string {
+	names := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		names = append(names, ip.String())
+	}
+	return strings.Join(names, ", ")
+}
+
+func (c CertificateCheck) Validate() error {
+	if len(c.Address) == 0 {
+		return fmt.Errorf("missing required argument: address")
+	}
+
+	if _, _, err := net.SplitHostPort(c.Address); err != nil {
+		return err
+	}
+
+	switch c.StartTls {
+	case "", startTlsSmtp, startTlsImap, startTlsLdap:
+	default:
+		return fmt.Errorf("invalid value for starttls: %q, expected %q, %q or %q", c.StartTls, startTlsSmtp, startTlsImap, startTlsLdap)
+	}
+
+	if len(c.CaFile) > 0 {
+		if _, err := c.roots(); err != nil {
+			return fmt.Errorf("unable to load ca_file: %v", err)
+		}
+	}
+
+	return nil
+}
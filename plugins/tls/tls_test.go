@@ -0,0 +1,283 @@
+package tls
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"chameth.com/goplum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCa is a certificate authority used to issue certificates for test servers.
+type testCa struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	file string
+}
+
+func newTestCa(t *testing.T, notAfter time.Time) *testCa {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Goplum Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+
+	return &testCa{cert: cert, key: key, file: file}
+}
+
+// issue creates a certificate for the given DNS names, signed by the CA.
+func (c *testCa) issue(t *testing.T, notAfter time.Time, names ...string) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: names[0]},
+		DNSNames:     names,
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, c.cert, &key.PublicKey, c.key)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// serve starts a server that accepts connections, performs any plain-text preamble using the given function, and
+// then completes a TLS handshake. Returns the address of the server.
+func serve(t *testing.T, cert tls.Certificate, preamble func(conn net.Conn, r *bufio.Reader)) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				if preamble != nil {
+					preamble(conn, bufio.NewReader(conn))
+				}
+
+				server := tls.Server(conn, &tls.Config{Certificates: []tls.Certificate{cert}})
+				if server.Handshake() == nil {
+					// Give the client a chance to read everything before closing the connection.
+					_, _ = server.Read(make([]byte, 1))
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestCertificateCheck_Execute(t *testing.T) {
+	year := time.Now().Add(365 * 24 * time.Hour)
+	week := time.Now().Add(7 * 24 * time.Hour)
+
+	ca := newTestCa(t, year)
+	shortCa := newTestCa(t, week)
+	other := newTestCa(t, year)
+
+	tests := []struct {
+		name       string
+		cert       tls.Certificate
+		ca         *testCa
+		serverName string
+		validity   time.Duration
+		expected   goplum.CheckState
+		detail     string
+	}{
+		{"Valid", ca.issue(t, year, "example.com"), ca, "example.com", 0, goplum.StateGood, ""},
+		{"LeafExpiringSoon", ca.issue(t, week, "example.com"), ca, "example.com", 0, goplum.StateWarning, "Certificate 'CN=example.com' expires in"},
+		{"RootExpiringSoon", shortCa.issue(t, year, "example.com"), shortCa, "example.com", 0, goplum.StateWarning, "Certificate 'CN=Goplum Test CA' expires in"},
+		{"BelowValidity", ca.issue(t, week, "example.com"), ca, "example.com", 10 * 24 * time.Hour, goplum.StateFailing, "Certificate 'CN=example.com' expires in"},
+		{"WrongHostname", ca.issue(t, year, "example.com"), ca, "example.net", 0, goplum.StateFailing, "Certificate verification failed: x509: certificate is valid for example.com, not example.net"},
+		{"Untrusted", ca.issue(t, year, "example.com"), other, "example.com", 0, goplum.StateFailing, "Certificate verification failed: x509: certificate signed by unknown authority"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check := Plugin{}.Check("certificate").(CertificateCheck)
+			check.Address = serve(t, test.cert, nil)
+			check.ServerName = test.serverName
+			check.CaFile = test.ca.file
+			check.CertificateValidity = test.validity
+			require.NoError(t, check.Validate())
+
+			result := check.Execute(context.Background())
+			assert.Equal(t, test.expected, result.State)
+			assert.True(t, strings.HasPrefix(result.Detail, test.detail), "unexpected detail: %s", result.Detail)
+			assert.Equal(t, "CN=Goplum Test CA", result.Facts[Issuer])
+			assert.Equal(t, "example.com, 127.0.0.1", result.Facts[SubjectAlternativeNames])
+			assert.NotEmpty(t, result.Facts[NotAfter])
+		})
+	}
+}
+
+func TestCertificateCheck_StartTls(t *testing.T) {
+	ca := newTestCa(t, time.Now().Add(365*24*time.Hour))
+	cert := ca.issue(t, time.Now().Add(365*24*time.Hour), "mail.example.com")
+
+	tests := []struct {
+		name     string
+		starttls string
+		preamble func(conn net.Conn, r *bufio.Reader)
+	}{
+		{
+			name:     "Smtp",
+			starttls: startTlsSmtp,
+			preamble: func(conn net.Conn, r *bufio.Reader) {
+				_, _ = conn.Write([]byte("220 mail.example.com ESMTP\r\n"))
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+
+					switch {
+					case strings.HasPrefix(line, "EHLO"):
+						_, _ = conn.Write([]byte("250-mail.example.com\r\n250-PIPELINING\r\n250 STARTTLS\r\n"))
+					case strings.HasPrefix(line, "STARTTLS"):
+						_, _ = conn.Write([]byte("220 Ready to start TLS\r\n"))
+						return
+					default:
+						_, _ = conn.Write([]byte("502 Unknown command\r\n"))
+					}
+				}
+			},
+		},
+		{
+			name:     "Imap",
+			starttls: startTlsImap,
+			preamble: func(conn net.Conn, r *bufio.Reader) {
+				_, _ = conn.Write([]byte("* OK IMAP4rev1 Service Ready\r\n"))
+				line, _ := r.ReadString('\n')
+				tag, _, _ := strings.Cut(line, " ")
+				_, _ = conn.Write([]byte(tag + " OK Begin TLS negotiation now\r\n"))
+			},
+		},
+		{
+			name:     "Ldap",
+			starttls: startTlsLdap,
+			preamble: func(conn net.Conn, r *bufio.Reader) {
+				request := make([]byte, len(ldapStartTlsRequest))
+				if _, err := r.Read(request); err != nil {
+					return
+				}
+				// An ExtendedResponse for message 1 with a result code of success and empty DN/message.
+				_, _ = conn.Write([]byte{0x30, 0x0c, 0x02, 0x01, 0x01, 0x78, 0x07, 0x0a, 0x01, 0x00, 0x04, 0x00, 0x04, 0x00})
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check := Plugin{}.Check("certificate").(CertificateCheck)
+			check.Address = serve(t, cert, test.preamble)
+			check.ServerName = "mail.example.com"
+			check.StartTls = test.starttls
+			check.CaFile = ca.file
+			require.NoError(t, check.Validate())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			result := check.Execute(ctx)
+			assert.Equal(t, goplum.StateGood, result.State, result.Detail)
+			assert.Equal(t, "CN=mail.example.com", result.Facts[Subject])
+		})
+	}
+}
+
+func TestReadBer(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		tag     byte
+		content string
+		error   string
+	}{
+		{"Short", "\x78\x03\x0a\x01\x00", 0x78, "\x0a\x01\x00", ""},
+		{"Long", "\x30\x81\x03abc", 0x30, "abc", ""},
+		{"Truncated", "\x30\x05abc", 0, "", "unexpected EOF"},
+		{"IndefiniteLength", "\x30\x80", 0, "", "unsupported length encoding"},
+		{"TooLarge", "\x30\x84\xff\xff\xff\xff", 0, "", "element too large"},
+		{"JustTooLarge", "\x30\x83\x01\x00\x01", 0, "", "element too large"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tag, content, err := readBer(bufio.NewReader(strings.NewReader(test.input)))
+			if test.error != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.error)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.tag, tag)
+			assert.Equal(t, test.content, string(content))
+		})
+	}
+}
+
+func TestCertificateCheck_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		check CertificateCheck
+		error string
+	}{
+		{"MissingAddress", CertificateCheck{}, "missing required argument: address"},
+		{"MissingPort", CertificateCheck{Address: "example.com"}, "missing port"},
+		{"InvalidStartTls", CertificateCheck{Address: "example.com:25", StartTls: "pop3"}, "invalid value for starttls"},
+		{"MissingCaFile", CertificateCheck{Address: "example.com:443", CaFile: "/does/not/exist"}, "unable to load ca_file"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.check.Validate()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), test.error)
+		})
+	}
+}
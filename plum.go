@@ -5,10 +5,12 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"maps"
 	"os"
 	"os/signal"
 	"reflect"
 	"regexp"
+	"slices"
 	"strings"
 	"sync"
 	"syscall"
@@ -30,8 +32,11 @@ type CheckSettings struct {
 	Interval         time.Duration
 	Timeout          time.Duration
 	Reminder         time.Duration
-	GoodThreshold    int `config:"good_threshold"`
-	FailingThreshold int `config:"failing_threshold"`
+	DependsOn        []string `config:"depends_on"`
+	EscalationPolicy string   `config:"escalation_policy"`
+	GoodThreshold    int      `config:"good_threshold"`
+	WarningThreshold int      `config:"warning_threshold"`
+	FailingThreshold int      `config:"failing_threshold"`
 }
 
 type Group struct {
@@ -90,6 +95,9 @@ func (p *Plum) shouldSendAlert(groupNames []string) (bool, string, string) {
 
 	var warnings []string
 
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	// Check each group - if ANY group forbids sending, we don't send
 	for _, groupName := range groupNames {
 		group, exists := p.Groups[groupName]
@@ -123,13 +131,19 @@ func (c CheckSettings) Copy() CheckSettings {
 	groups := make([]string, len(c.Groups))
 	copy(groups, c.Groups)
 
+	dependsOn := make([]string, len(c.DependsOn))
+	copy(dependsOn, c.DependsOn)
+
 	return CheckSettings{
 		Alerts:           alerts,
 		Groups:           groups,
 		Interval:         c.Interval,
 		Timeout:          c.Timeout,
 		Reminder:         c.Reminder,
+		DependsOn:        dependsOn,
+		EscalationPolicy: c.EscalationPolicy,
 		GoodThreshold:    c.GoodThreshold,
+		WarningThreshold: c.WarningThreshold,
 		FailingThreshold: c.FailingThreshold,
 	}
 }
@@ -139,33 +153,62 @@ var DefaultSettings = CheckSettings{
 	Interval:         time.Second * 30,
 	Timeout:          time.Second * 20,
 	GoodThreshold:    2,
+	WarningThreshold: 2,
 	FailingThreshold: 2,
 }
 
 type PluginLoader func() (Plugin, error)
 type CheckListener func(*ScheduledCheck, Result)
 
+// AlertListener is notified whenever an alert is sent, with the name of the alert, the details that were sent to
+// it, and the error it returned (if any).
+type AlertListener func(string, AlertDetails, error)
+
 type Plum struct {
 	Alerts           map[string]Alert
 	Checks           map[string]*ScheduledCheck
 	Groups           map[string]*Group
+	Maintenance      map[string]*MaintenanceWindow
+	Escalations      map[string]*Escalation
 	availablePlugins map[string]PluginLoader
 	loadedPlugins    map[string]Plugin
+	pluginSettings   map[string]map[string]any
 	checkDefaults    CheckSettings
+	configPath       string
 	scheduled        chan *ScheduledCheck
+	wake             chan struct{}
 	checkListeners   map[reflect.Value]CheckListener
+	alertListeners   map[reflect.Value]AlertListener
+	history          HistoryStore
+
+	// mu guards the Alerts, Checks, Groups, Maintenance and Escalations maps, which are replaced when the config
+	// is reloaded, and the state, suspension and acknowledgement details of each check.
+	mu sync.RWMutex
+
+	// reloadMu serialises config reloads.
+	reloadMu sync.Mutex
+	// reloading stops the scheduler from running any checks while a reload waits for in-flight checks to finish.
+	// It is guarded by mu.
+	reloading bool
+	// inflight tracks checks that have been scheduled but not yet finished running.
+	inflight sync.WaitGroup
 }
 
 func NewPlum() *Plum {
 	plum := &Plum{
 		availablePlugins: make(map[string]PluginLoader),
 		loadedPlugins:    make(map[string]Plugin),
+		pluginSettings:   make(map[string]map[string]any),
 		Alerts:           make(map[string]Alert),
 		Checks:           make(map[string]*ScheduledCheck),
 		Groups:           make(map[string]*Group),
+		Maintenance:      make(map[string]*MaintenanceWindow),
+		Escalations:      make(map[string]*Escalation),
 		checkDefaults:    DefaultSettings.Copy(),
 		scheduled:        make(chan *ScheduledCheck, 100),
+		wake:             make(chan struct{}, 1),
 		checkListeners:   make(map[reflect.Value]CheckListener),
+		alertListeners:   make(map[reflect.Value]AlertListener),
 	}
 
 	plum.AddCheckListener(plum.updateStatus)
@@ -184,15 +227,156 @@ func (p *Plum) RegisterPlugin(name string, loader PluginLoader) {
 	p.availablePlugins[name] = loader
 }
 
+// ReadConfig parses the config file at the given path, and replaces all alerts, groups, checks and maintenance
+// windows with the ones it defines (ad-hoc maintenance windows are kept). If the config can't be parsed or fails
+// validation, an error is returned and the existing config remains in place.
+//
+// Checks that exist in both the old and new config and whose settings are unchanged keep their state and history;
+// checks whose settings have changed start afresh, but remain suspended if they were previously. Any checks that
+// are running when the config is read are allowed to finish before their state is carried over.
 func (p *Plum) ReadConfig(path string) error {
-	f, err := os.Open(path)
-	if err != nil {
+	p.reloadMu.Lock()
+	defer p.reloadMu.Unlock()
+
+	staged := &Plum{
+		availablePlugins: p.availablePlugins,
+		loadedPlugins:    maps.Clone(p.loadedPlugins),
+		pluginSettings:   maps.Clone(p.pluginSettings),
+		Alerts:           make(map[string]Alert),
+		Checks:           make(map[string]*ScheduledCheck),
+		Groups:           make(map[string]*Group),
+		Maintenance:      make(map[string]*MaintenanceWindow),
+		Escalations:      make(map[string]*Escalation),
+		checkDefaults:    DefaultSettings.Copy(),
+	}
+
+	if err := staged.parseConfig(path); err != nil {
 		return err
 	}
-	defer f.Close()
 
-	parser := config.NewParser(f)
-	if err := parser.Parse(); err != nil {
+	// Stop scheduling checks and wait for any that are already queued or running, so their results are recorded
+	// before the state is transferred.
+	p.mu.Lock()
+	p.reloading = true
+	p.mu.Unlock()
+	p.inflight.Wait()
+
+	p.mu.Lock()
+	added, changed, removed := p.transferState(staged)
+	p.Alerts = staged.Alerts
+	p.Checks = staged.Checks
+	p.Groups = staged.Groups
+	p.Maintenance = staged.Maintenance
+	p.Escalations = staged.Escalations
+	p.loadedPlugins = staged.loadedPlugins
+	p.pluginSettings = staged.pluginSettings
+	p.checkDefaults = staged.checkDefaults
+	p.configPath = path
+	p.reloading = false
+	p.registerChecks()
+	p.mu.Unlock()
+
+	if added+changed+removed > 0 {
+		log.Printf("Loaded config from %s: %d checks added, %d changed, %d removed", path, added, changed, removed)
+	}
+
+	// Wake the scheduler up so any new checks are run promptly.
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// registerChecks passes each loaded plugin that implements Registrar the checks it created. The caller must hold
+// the write lock.
+func (p *Plum) registerChecks() {
+	for name, plugin := range p.loadedPlugins {
+		registrar, ok := plugin.(Registrar)
+		if !ok {
+			continue
+		}
+
+		var checks []Check
+		for _, c := range p.Checks {
+			if strings.HasPrefix(c.Type, name+".") {
+				checks = append(checks, c.Check)
+			}
+		}
+		registrar.RegisterChecks(checks)
+	}
+}
+
+// ReloadConfig re-reads the config file previously passed to ReadConfig.
+func (p *Plum) ReloadConfig() error {
+	p.mu.RLock()
+	path := p.configPath
+	p.mu.RUnlock()
+
+	if path == "" {
+		return fmt.Errorf("no config file has been loaded")
+	}
+
+	return p.ReadConfig(path)
+}
+
+// transferState carries over state from the current checks and groups to the staged ones, returning the number of
+// checks that have been added, changed and removed. The caller must hold the write lock, and no checks may be
+// running.
+func (p *Plum) transferState(staged *Plum) (added, changed, removed int) {
+	for name, check := range staged.Checks {
+		old, ok := p.Checks[name]
+		if !ok {
+			added++
+			continue
+		}
+
+		if old.Type == check.Type && reflect.DeepEqual(old.rawConfig, check.rawConfig) && reflect.DeepEqual(old.Config, check.Config) {
+			newCheckTombStone(old).restore(check)
+		} else {
+			log.Printf("Check %s has been reconfigured, its state will be reset", name)
+			check.Suspended = old.Suspended
+			check.SuspendedUntil = old.SuspendedUntil
+			check.SuspendedReason = old.SuspendedReason
+			check.SuspendedBy = old.SuspendedBy
+			changed++
+		}
+	}
+
+	for name := range p.Checks {
+		if _, ok := staged.Checks[name]; !ok {
+			removed++
+		}
+	}
+
+	for name, group := range staged.Groups {
+		if old, ok := p.Groups[name]; ok {
+			old.mu.RLock()
+			group.alertHistory = slices.Clone(old.alertHistory)
+			old.mu.RUnlock()
+		}
+	}
+
+	for name, window := range p.Maintenance {
+		if !window.AdHoc {
+			continue
+		}
+
+		if _, ok := staged.Maintenance[name]; ok {
+			log.Printf("Maintenance window %s is now defined in the config, replacing the ad-hoc window", name)
+			continue
+		}
+
+		staged.Maintenance[name] = window
+	}
+
+	return
+}
+
+func (p *Plum) parseConfig(path string) error {
+	parser, err := config.ParseFile(path)
+	if err != nil {
 		return fmt.Errorf("unable to parse config file %s: %v", path, err)
 	}
 
@@ -208,10 +392,22 @@ func (p *Plum) ReadConfig(path string) error {
 		return err
 	}
 
+	if err := p.addEscalations(parser.EscalationBlocks); err != nil {
+		return err
+	}
+
 	if err := p.addChecks(parser.CheckBlocks); err != nil {
 		return err
 	}
 
+	if err := p.validateDependencies(); err != nil {
+		return err
+	}
+
+	if err := p.addMaintenanceWindows(parser.MaintenanceBlocks); err != nil {
+		return err
+	}
+
 	if err := p.configurePlugins(parser.PluginSettings); err != nil {
 		return err
 	}
@@ -225,11 +421,41 @@ func (p *Plum) RestoreState() error {
 		return err
 	}
 
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Ad-hoc maintenance windows have their own expiry, so are restored regardless of the age of the tombstone.
+	for _, window := range ts.Maintenance {
+		if _, ok := p.Maintenance[window.Name]; ok {
+			continue
+		}
+
+		if err := window.Validate(); err != nil {
+			log.Printf("Unable to restore maintenance window %s: %v", window.Name, err)
+			continue
+		}
+
+		if !window.Expired(time.Now()) {
+			window.AdHoc = true
+			p.Maintenance[window.Name] = window
+		}
+	}
+
 	return ts.Restore(p.Checks)
 }
 
 func (p *Plum) SaveState() error {
-	return NewTombStone(p.Checks).Save()
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	ts := NewTombStone(p.Checks)
+	for _, window := range p.Maintenance {
+		if window.AdHoc {
+			ts.Maintenance = append(ts.Maintenance, window)
+		}
+	}
+
+	return ts.Save()
 }
 
 func (p *Plum) addAlerts(alerts []*config.Block) error {
@@ -367,25 +593,122 @@ func (p *Plum) addChecks(checks []*config.Block) error {
 			}
 		}
 
+		if settings.EscalationPolicy != "" {
+			if _, ok := p.Escalations[settings.EscalationPolicy]; !ok {
+				return fmt.Errorf("error configuring check %s: no escalation named '%s'", checks[i].Name, settings.EscalationPolicy)
+			}
+		}
+
 		p.Checks[checks[i].Name] = &ScheduledCheck{
-			Name:   checks[i].Name,
-			Type:   checks[i].Type,
-			Config: &settings,
-			Check:  check,
+			Name:      checks[i].Name,
+			Type:      checks[i].Type,
+			Config:    &settings,
+			Check:     check,
+			rawConfig: checks[i].Settings,
+		}
+	}
+
+	return nil
+}
+
+// validateDependencies ensures that all checks named in depends_on settings exist, and that there are no cycles.
+func (p *Plum) validateDependencies() error {
+	for _, name := range slices.Sorted(maps.Keys(p.Checks)) {
+		for _, parent := range p.Checks[name].Config.DependsOn {
+			if _, ok := p.Checks[parent]; !ok {
+				return fmt.Errorf("error configuring check %s: no check named '%s' to depend on", name, parent)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int)
+	var path []string
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			start := slices.Index(path, name)
+			return fmt.Errorf("dependency cycle between checks: %s", strings.Join(append(path[start:], name), " -> "))
+		case visited:
+			return nil
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, parent := range p.Checks[name].Config.DependsOn {
+			if err := visit(parent); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(p.Checks)) {
+		if err := visit(name); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+// failingDependency returns the name of a check that the given check depends on (directly or indirectly) that is
+// currently failing, or an empty string if there is none.
+func (p *Plum) failingDependency(c *ScheduledCheck) string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	queue := slices.Clone(c.Config.DependsOn)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		parent, ok := p.Checks[name]
+		if !ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		if parent.State == StateFailing {
+			return name
+		}
+		queue = append(queue, parent.Config.DependsOn...)
+	}
+
+	return ""
+}
+
+// configurePlugins applies settings to loaded plugins and validates them. Plugins are only configured once: if the
+// config is subsequently reloaded their settings are left alone, as they may have already acted on them (e.g. by
+// listening on a port).
 func (p *Plum) configurePlugins(blocks []*config.Block) error {
+	settings := make(map[string]map[string]any)
+
 	for i := range blocks {
 		name := blocks[i].Type
+		if previous, ok := p.pluginSettings[name]; ok {
+			if !reflect.DeepEqual(previous, blocks[i].Settings) {
+				log.Printf("Settings for plugin %s have changed, goplum must be restarted to apply them", name)
+			}
+			continue
+		}
+
 		loaded, ok := p.loadedPlugins[name]
 		if ok {
 			if err := internal.DecodeSettings(&blocks[i].Settings, &loaded); err != nil {
 				return fmt.Errorf("error configuring plugin %s: %v", name, err)
 			}
+			settings[name] = blocks[i].Settings
 			continue
 		}
 
@@ -397,11 +720,17 @@ func (p *Plum) configurePlugins(blocks []*config.Block) error {
 	}
 
 	for name := range p.loadedPlugins {
+		if _, ok := p.pluginSettings[name]; ok {
+			continue
+		}
+
 		if v, ok := p.loadedPlugins[name].(Validator); ok {
 			if err := v.Validate(); err != nil {
 				return fmt.Errorf("error configuring plugin %s: %v", name, err)
 			}
 		}
+
+		p.pluginSettings[name] = settings[name]
 	}
 
 	return nil
@@ -432,38 +761,74 @@ func (p *Plum) Run() {
 	}
 
 	for {
-		min := time.Now().Add(time.Hour)
-		for i := range p.Checks {
-			c := p.Checks[i]
-
-			if c.Suspended {
-				// If a check is suspended, don't wait more than a minute before we check again.
-				if next := time.Now().Add(time.Minute); next.Before(min) {
-					min = next
-				}
-				continue
-			}
+		due, next := p.dueChecks(time.Now())
 
-			remaining := c.Remaining()
-			if remaining <= 0 {
-				c.Scheduled = true
-				p.scheduled <- c
-				remaining = c.Remaining()
-			}
+		// Queue checks outside the lock, as the runners may need it to finish processing earlier checks.
+		for i := range due {
+			p.scheduled <- due[i]
+		}
+
+		select {
+		case <-time.After(time.Until(next)):
+		case <-p.wake:
+		}
+	}
+}
+
+// dueChecks marks checks that are due to run as scheduled and returns them, along with the time the next check
+// will be due. Checks whose suspensions have expired are unsuspended. No checks are returned while the config is
+// being reloaded; the scheduler is woken once the reload finishes.
+func (p *Plum) dueChecks(now time.Time) ([]*ScheduledCheck, time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.reloading {
+		return nil, now.Add(time.Minute)
+	}
 
-			if next := time.Now().Add(remaining); next.Before(min) {
+	p.pruneMaintenanceWindows(now)
+
+	var due []*ScheduledCheck
+	min := now.Add(time.Hour)
+	for i := range p.Checks {
+		c := p.Checks[i]
+
+		if c.checkSuspension(now) || p.maintenanceFor(c.Name, now) != nil {
+			// If a check is suspended or in maintenance, don't wait more than a minute before we check again.
+			next := now.Add(time.Minute)
+			if c.Suspended && !c.SuspendedUntil.IsZero() && c.SuspendedUntil.Before(next) {
+				next = c.SuspendedUntil
+			}
+			if next.Before(min) {
 				min = next
 			}
+			continue
+		}
+
+		remaining := c.Remaining()
+		if remaining <= 0 {
+			c.Scheduled = true
+			due = append(due, c)
+			remaining = c.Remaining()
 		}
 
-		time.Sleep(time.Until(min))
+		if next := time.Now().Add(remaining); next.Before(min) {
+			min = next
+		}
 	}
+
+	p.inflight.Add(len(due))
+	return due, min
 }
 
 func (p *Plum) processScheduledChecks() {
 	for c := range p.scheduled {
 		p.RunCheck(c)
+
+		p.mu.Lock()
 		c.Scheduled = false
+		p.mu.Unlock()
+		p.inflight.Done()
 	}
 }
 
@@ -493,7 +858,9 @@ func (p *Plum) RunCheck(c *ScheduledCheck) {
 	}
 	result.Facts[CheckTime] = time.Since(start)
 
+	p.mu.Lock()
 	c.AddResult(&result)
+	p.mu.Unlock()
 
 	for _, listener := range p.checkListeners {
 		listener(c, result)
@@ -504,17 +871,54 @@ func (p *Plum) updateStatus(c *ScheduledCheck, _ Result) {
 	oldState := c.State
 	newState := c.History.State(map[CheckState]int{
 		StateFailing: c.Config.FailingThreshold,
+		StateWarning: c.Config.WarningThreshold,
 		StateGood:    c.Config.GoodThreshold,
 	})
+
+	p.mu.Lock()
 	if newState != oldState {
 		c.State = newState
+
+		// An acknowledgement only covers the problem that was acknowledged, so it's cleared if the check recovers
+		// or gets worse.
+		if newState == StateGood || newState.Severity() > oldState.Severity() {
+			c.clearAcknowledgement()
+		}
+	}
+	acknowledged := c.Acknowledged
+	p.mu.Unlock()
+
+	if newState != oldState {
 		if c.Settled {
 			p.raiseAlerts(c, oldState, false)
 		} else {
 			c.Settled = true
 		}
-	} else if newState == StateFailing && c.Settled && c.Config.Reminder > 0 && time.Since(c.LastAlertTime) >= c.Config.Reminder {
-		p.raiseAlerts(c, oldState, true)
+	} else if newState == StateFailing && c.Settled && !acknowledged {
+		if !p.escalate(c) && c.Config.Reminder > 0 && time.Since(c.LastAlertTime) >= c.Config.Reminder {
+			p.raiseAlerts(c, oldState, true)
+		}
+	}
+}
+
+// SetHistoryStore configures a store that will be used to persist the results of all checks.
+func (p *Plum) SetHistoryStore(store HistoryStore) {
+	p.history = store
+	p.AddCheckListener(p.recordHistory)
+}
+
+// QueryHistory returns the results of the named check between the given times from the history store.
+func (p *Plum) QueryHistory(check string, from, to time.Time) ([]Result, error) {
+	if p.history == nil {
+		return nil, fmt.Errorf("history is not enabled")
+	}
+
+	return p.history.Query(check, from, to)
+}
+
+func (p *Plum) recordHistory(c *ScheduledCheck, result Result) {
+	if err := p.history.Record(c.Name, result); err != nil {
+		log.Printf("Unable to record history for check %s: %v", c.Name, err)
 	}
 }
 
@@ -529,6 +933,40 @@ func (p *Plum) RaiseAlerts(c *ScheduledCheck, previousState CheckState) {
 }
 
 func (p *Plum) raiseAlerts(c *ScheduledCheck, previousState CheckState, isReminder bool) {
+	targets, level := p.alertTargets(c, previousState, isReminder)
+	if p.sendAlerts(c, previousState, isReminder, targets) {
+		p.setEscalation(c, c.FailingSince, level)
+	}
+}
+
+// sendAlerts sends details of the check's state to all alerts matching the given names, unless alerts for the
+// check are currently suppressed. Returns true if the alerts were sent.
+func (p *Plum) sendAlerts(c *ScheduledCheck, previousState CheckState, isReminder bool, targets []string) bool {
+	if len(targets) == 0 {
+		return false
+	}
+
+	if window := p.InMaintenance(c.Name); window != nil {
+		log.Printf("Alert for %s suppressed due to maintenance window %s\n", c.Name, window.Name)
+		return false
+	}
+
+	if parent := p.failingDependency(c); parent != "" {
+		log.Printf("Alert for %s suppressed as it depends on %s, which is failing\n", c.Name, parent)
+		if c.State == StateFailing && !isReminder {
+			p.setFailureSuppressed(c, true)
+		} else if previousState == StateFailing && c.State != StateFailing {
+			p.setFailureSuppressed(c, false)
+		}
+		return false
+	}
+
+	if previousState == StateFailing && c.State != StateFailing && c.FailureSuppressed {
+		log.Printf("Recovery alert for %s suppressed as its failure was not alerted\n", c.Name)
+		p.setFailureSuppressed(c, false)
+		return false
+	}
+
 	details := AlertDetails{
 		Name:          c.Name,
 		Config:        c.Check,
@@ -557,7 +995,7 @@ func (p *Plum) raiseAlerts(c *ScheduledCheck, previousState CheckState, isRemind
 	shouldSend, suppressionWarning, suppressingGroup := p.shouldSendAlert(c.Config.Groups)
 	if !shouldSend {
 		log.Printf("Alert for %s suppressed due to group limit (group: %s)\n", c.Name, suppressingGroup)
-		return
+		return false
 	}
 
 	// Add suppression warning if this is the last alert before throttling
@@ -565,23 +1003,49 @@ func (p *Plum) raiseAlerts(c *ScheduledCheck, previousState CheckState, isRemind
 		details.Text += suppressionWarning
 	}
 
-	alerts := p.AlertsMatching(c.Config.Alerts)
-	log.Printf("Raising alerts for %s: %d alerts match config %v\n", c.Name, len(alerts), c.Config.Alerts)
+	alerts := p.namedAlertsMatching(targets)
+	log.Printf("Raising alerts for %s: %d alerts match config %v\n", c.Name, len(alerts), targets)
 	for n := range alerts {
-		if err := alerts[n].Send(details); err != nil {
+		err := alerts[n].Send(details)
+		if err != nil {
 			log.Printf("Error sending alert: %v\n", err)
 		}
+
+		for _, listener := range p.alertListeners {
+			listener(n, details, err)
+		}
 	}
 
+	p.mu.Lock()
 	c.LastAlertTime = time.Now()
+	if c.State == StateFailing {
+		c.FailureSuppressed = false
+	}
+	p.mu.Unlock()
+	return true
+}
+
+// setFailureSuppressed records whether the check's current failure went unalerted.
+func (p *Plum) setFailureSuppressed(c *ScheduledCheck, suppressed bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	c.FailureSuppressed = suppressed
 }
 
 func (p *Plum) AlertsMatching(names []string) []Alert {
-	var res []Alert
+	return slices.Collect(maps.Values(p.namedAlertsMatching(names)))
+}
+
+// namedAlertsMatching returns all alerts whose names match the given wildcards, keyed by name.
+func (p *Plum) namedAlertsMatching(names []string) map[string]Alert {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	res := make(map[string]Alert)
 	re := regexpForWildcards(names)
 	for j := range p.Alerts {
 		if re.MatchString(j) {
-			res = append(res, p.Alerts[j])
+			res[j] = p.Alerts[j]
 		}
 	}
 	return res
@@ -595,12 +1059,32 @@ func (p *Plum) RemoveCheckListener(listener CheckListener) {
 	delete(p.checkListeners, reflect.ValueOf(listener))
 }
 
-// Suspend sets the check with the given name to be suspended (i.e., it won't run until unsuspended).
+func (p *Plum) AddAlertListener(listener AlertListener) {
+	p.alertListeners[reflect.ValueOf(listener)] = listener
+}
+
+func (p *Plum) RemoveAlertListener(listener AlertListener) {
+	delete(p.alertListeners, reflect.ValueOf(listener))
+}
+
+// Suspend sets the check with the given name to be suspended (i.e., it won't run until unsuspended). If the
+// duration is non-zero, the check will automatically be unsuspended once it has elapsed. The reason and the
+// name of whoever suspended the check are optional, and are recorded for informational purposes.
 // Returns the modified check, or nil if the check didn't exist.
-func (p *Plum) Suspend(checkName string) *ScheduledCheck {
+func (p *Plum) Suspend(checkName string, duration time.Duration, reason, by string) *ScheduledCheck {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	if check, ok := p.Checks[checkName]; ok {
-		log.Printf("Check %s has been suspended", checkName)
 		check.Suspended = true
+		check.SuspendedReason = reason
+		check.SuspendedBy = by
+		check.SuspendedUntil = time.Time{}
+		if duration > 0 {
+			check.SuspendedUntil = time.Now().Add(duration)
+		}
+
+		log.Printf("Check %s has been suspended%s", checkName, check.suspensionDetails())
 		return check
 	}
 	return nil
@@ -609,14 +1093,47 @@ func (p *Plum) Suspend(checkName string) *ScheduledCheck {
 // Unsuspend sets the check with the given name to be resumed (i.e., it will run normally).
 // Returns the modified check, or nil if the check didn't exist.
 func (p *Plum) Unsuspend(checkName string) *ScheduledCheck {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	if check, ok := p.Checks[checkName]; ok {
 		log.Printf("Check %s has been unsuspended", checkName)
-		check.Suspended = false
+		check.unsuspend()
 		return check
 	}
 	return nil
 }
 
+// Acknowledge records that someone is dealing with the current failure of the check with the given name. No
+// further reminders or escalations will be sent for the check until it has recovered. The message and the name
+// of whoever acknowledged the check are optional. Returns the modified check, or an error if the check doesn't
+// exist or isn't currently failing or warning.
+func (p *Plum) Acknowledge(checkName, message, by string) (*ScheduledCheck, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	check, ok := p.Checks[checkName]
+	if !ok {
+		return nil, fmt.Errorf("no check found with name: %s", checkName)
+	}
+
+	if check.State != StateFailing && check.State != StateWarning {
+		return nil, fmt.Errorf("check %s is not failing or warning", checkName)
+	}
+
+	check.Acknowledged = true
+	check.AcknowledgedAt = time.Now()
+	check.AcknowledgedBy = by
+	check.AcknowledgedMessage = message
+
+	if by != "" {
+		log.Printf("Check %s has been acknowledged by %s", checkName, by)
+	} else {
+		log.Printf("Check %s has been acknowledged", checkName)
+	}
+	return check, nil
+}
+
 // regexpForWildcards converts a set of names containing '*' characters as wildcards into a single regex that will
 // match any of them.
 //
@@ -655,6 +1172,67 @@ type ScheduledCheck struct {
 	State         CheckState
 	Suspended     bool
 	History       ResultHistory
+
+	// Details of why the check was suspended, and when it will be automatically unsuspended (if ever).
+	SuspendedUntil  time.Time
+	SuspendedReason string
+	SuspendedBy     string
+
+	// Escalation state for the current failure, if the check has an escalation policy.
+	FailingSince    time.Time
+	EscalationLevel int
+
+	// FailureSuppressed records that the alert for the current failure was suppressed because a dependency was
+	// failing, in which case no recovery alert is sent either.
+	FailureSuppressed bool
+
+	// Details of who acknowledged the current failure, if anyone. Acknowledged checks don't send reminders or
+	// escalate further, and the acknowledgement is cleared when the check returns to good or gets worse.
+	Acknowledged        bool
+	AcknowledgedAt      time.Time
+	AcknowledgedBy      string
+	AcknowledgedMessage string
+
+	// rawConfig is the check's block from the config file, used to detect changes when the config is reloaded.
+	rawConfig map[string]any
+}
+
+// checkSuspension unsuspends the check if its suspension has expired, and returns whether it is still suspended.
+func (c *ScheduledCheck) checkSuspension(now time.Time) bool {
+	if c.Suspended && !c.SuspendedUntil.IsZero() && !now.Before(c.SuspendedUntil) {
+		log.Printf("Suspension of check %s has expired", c.Name)
+		c.unsuspend()
+	}
+	return c.Suspended
+}
+
+func (c *ScheduledCheck) clearAcknowledgement() {
+	c.Acknowledged = false
+	c.AcknowledgedAt = time.Time{}
+	c.AcknowledgedBy = ""
+	c.AcknowledgedMessage = ""
+}
+
+func (c *ScheduledCheck) unsuspend() {
+	c.Suspended = false
+	c.SuspendedUntil = time.Time{}
+	c.SuspendedReason = ""
+	c.SuspendedBy = ""
+}
+
+// suspensionDetails describes who suspended the check, why, and for how long, for use in log messages.
+func (c *ScheduledCheck) suspensionDetails() string {
+	var res string
+	if c.SuspendedBy != "" {
+		res += fmt.Sprintf(" by %s", c.SuspendedBy)
+	}
+	if !c.SuspendedUntil.IsZero() {
+		res += fmt.Sprintf(" until %s", c.SuspendedUntil.Format(time.DateTime))
+	}
+	if c.SuspendedReason != "" {
+		res += fmt.Sprintf(": %s", c.SuspendedReason)
+	}
+	return res
 }
 
 func (c *ScheduledCheck) Remaining() time.Duration {
@@ -664,6 +1242,8 @@ func (c *ScheduledCheck) Remaining() time.Duration {
 	return time.Until(c.LastRun.Add(c.Config.Interval))
 }
 
+// AddResult records the result in the check's history. If the check belongs to a running Plum, the caller must
+// hold its write lock.
 func (c *ScheduledCheck) AddResult(result *Result) ResultHistory {
 	copy(c.History[1:9], c.History[0:8])
 	c.History[0] = result
@@ -702,8 +1282,8 @@ func (h ResultHistory) State(thresholds map[CheckState]int) CheckState {
 }
 
 // Run creates a new instance of Plum, registers plugins and loads configuration, and starts the main loop.
-// Listens for interrupt and sigterm signals in order to save state and clean up. It is expected that flag.Parse
-// has been called prior to calling this method.
+// Listens for interrupt and sigterm signals in order to save state and clean up, and for SIGHUP in order to
+// reload the config. It is expected that flag.Parse has been called prior to calling this method.
 func Run(plugins map[string]PluginLoader, configPath string) {
 	p := NewPlum()
 	p.RegisterPlugins(plugins)
@@ -716,16 +1296,46 @@ func Run(plugins map[string]PluginLoader, configPath string) {
 		log.Printf("Unable to restore state from tombstone: %v", err)
 	}
 
+	if *historyPath != "" {
+		store, err := NewFileHistoryStore(*historyPath, *historyRetention)
+		if err != nil {
+			log.Printf("Unable to open history store, history will not be recorded: %v", err)
+		} else {
+			p.SetHistoryStore(store)
+			defer store.Close()
+		}
+	}
+
 	api := NewGrpcServer(p)
 
 	go api.Start()
+
+	if *metricsPort != 0 {
+		metrics := NewMetricsServer(p)
+		go metrics.Start()
+		defer metrics.Stop()
+	}
+
 	go p.Run()
 
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt)
 	signal.Notify(c, syscall.SIGTERM)
 
-	<-c
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	for running := true; running; {
+		select {
+		case <-hup:
+			log.Printf("Received SIGHUP, reloading config from %s", configPath)
+			if err := p.ReloadConfig(); err != nil {
+				log.Printf("Unable to reload config: %v", err)
+			}
+		case <-c:
+			running = false
+		}
+	}
 
 	api.Stop()
 	if err := p.SaveState(); err != nil {
@@ -41,6 +41,15 @@ func TestReadConfig_GoldenData(t *testing.T) {
 		"groups-in-defaults",
 		"http-get-keys",
 		"invalid-group-in-defaults",
+		"maintenance",
+		"maintenance-invalid-schedule",
+		"maintenance-no-duration",
+		"dependencies",
+		"dependency-cycle",
+		"dependency-unknown",
+		"escalation",
+		"escalation-unknown",
+		"escalation-unknown-alert",
 	}
 	gold := goldie.New(t)
 
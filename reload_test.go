@@ -0,0 +1,132 @@
+package goplum_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"chameth.com/goplum"
+	"chameth.com/goplum/plugins/debug"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func TestReloadConfig_KeepsStateOfUnchangedChecks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goplum.conf")
+	writeConfig(t, path, `
+alert debug.sysout "debug" {}
+check debug.random "unchanged" { percent_good = 1.0 }
+check debug.random "changed" { percent_good = 1.0 }
+check debug.random "removed" { percent_good = 1.0 }
+`)
+
+	plum := goplum.NewPlum()
+	plum.RegisterPlugins(plugins)
+	require.NoError(t, plum.ReadConfig(path))
+
+	for _, name := range []string{"unchanged", "changed"} {
+		plum.RunCheck(plum.Checks[name])
+		plum.RunCheck(plum.Checks[name])
+		plum.Suspend(name, 0, "", "")
+	}
+
+	writeConfig(t, path, `
+alert debug.sysout "debug" {}
+check debug.random "unchanged" { percent_good = 1.0 }
+check debug.random "changed" { percent_good = 0.9 }
+check debug.random "added" { percent_good = 1.0 }
+`)
+	require.NoError(t, plum.ReloadConfig())
+
+	assert.Len(t, plum.Checks, 3)
+	assert.NotContains(t, plum.Checks, "removed")
+
+	unchanged := plum.Checks["unchanged"]
+	assert.Equal(t, goplum.StateGood, unchanged.State)
+	assert.True(t, unchanged.Settled)
+	assert.True(t, unchanged.Suspended)
+	assert.NotNil(t, unchanged.History[1])
+
+	changed := plum.Checks["changed"]
+	assert.Equal(t, goplum.StateIndeterminate, changed.State)
+	assert.False(t, changed.Settled)
+	assert.True(t, changed.Suspended)
+	assert.Nil(t, changed.History[0])
+
+	added := plum.Checks["added"]
+	assert.False(t, added.Suspended)
+}
+
+func TestReloadConfig_KeepsExistingConfigOnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goplum.conf")
+	writeConfig(t, path, `
+alert debug.sysout "debug" {}
+check debug.random "test" { percent_good = 1.0 }
+`)
+
+	plum := goplum.NewPlum()
+	plum.RegisterPlugins(plugins)
+	require.NoError(t, plum.ReadConfig(path))
+	original := plum.Checks["test"]
+
+	writeConfig(t, path, `
+alert debug.sysout "debug" {}
+check debug.random "test" { percent_good = 1.0 }
+check http.get "invalid" {}
+`)
+	assert.Error(t, plum.ReloadConfig())
+	assert.Len(t, plum.Checks, 1)
+	assert.Same(t, original, plum.Checks["test"])
+
+	writeConfig(t, path, `check debug.random "test" {`)
+	assert.Error(t, plum.ReloadConfig())
+	assert.Same(t, original, plum.Checks["test"])
+}
+
+// registrarPlugin records the checks most recently registered with it.
+type registrarPlugin struct {
+	debug.Plugin
+	registered []goplum.Check
+}
+
+func (p *registrarPlugin) RegisterChecks(checks []goplum.Check) {
+	p.registered = checks
+}
+
+func TestReloadConfig_RegistersChecksWithPlugins(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goplum.conf")
+	writeConfig(t, path, `
+alert debug.sysout "debug" {}
+check registrar.random "kept" { percent_good = 1.0 }
+check registrar.random "removed" { percent_good = 1.0 }
+check debug.random "other" { percent_good = 1.0 }
+`)
+
+	registrar := &registrarPlugin{}
+	plum := goplum.NewPlum()
+	plum.RegisterPlugins(plugins)
+	plum.RegisterPlugin("registrar", func() (goplum.Plugin, error) { return registrar, nil })
+	require.NoError(t, plum.ReadConfig(path))
+	assert.ElementsMatch(t, []goplum.Check{plum.Checks["kept"].Check, plum.Checks["removed"].Check}, registrar.registered)
+
+	writeConfig(t, path, `
+alert debug.sysout "debug" {}
+check registrar.random "kept" { percent_good = 1.0 }
+check debug.random "other" { percent_good = 1.0 }
+`)
+	require.NoError(t, plum.ReloadConfig())
+	assert.Equal(t, []goplum.Check{plum.Checks["kept"].Check}, registrar.registered)
+
+	writeConfig(t, path, `
+alert debug.sysout "debug" {}
+check registrar.random "kept" { percent_good = 1.0 }
+check http.get "invalid" {}
+`)
+	require.Error(t, plum.ReloadConfig())
+	assert.Equal(t, []goplum.Check{plum.Checks["kept"].Check}, registrar.registered)
+}
@@ -0,0 +1,218 @@
+package goplum
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockingPlugin provides a check that doesn't finish until it is released, and an alert that does nothing.
+type blockingPlugin struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (p blockingPlugin) Check(kind string) Check {
+	return &blockingCheck{started: p.started, release: p.release}
+}
+
+func (p blockingPlugin) Alert(kind string) Alert {
+	return nopAlert{}
+}
+
+type blockingCheck struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (c *blockingCheck) Execute(ctx context.Context) Result {
+	c.started <- struct{}{}
+	<-c.release
+	return GoodResult()
+}
+
+// goodCheck always passes.
+type goodCheck struct{}
+
+func (goodCheck) Execute(context.Context) Result {
+	return GoodResult()
+}
+
+// flappingCheck alternates between passing and failing.
+type flappingCheck struct {
+	runs *atomic.Int32
+}
+
+func (c flappingCheck) Execute(context.Context) Result {
+	if c.runs.Add(1)%2 == 0 {
+		return GoodResult()
+	}
+	return FailingResult("down")
+}
+
+type nopAlert struct{}
+
+func (nopAlert) Send(AlertDetails) error {
+	return nil
+}
+
+func TestPlum_ReloadWaitsForRunningChecks(t *testing.T) {
+	plugin := blockingPlugin{started: make(chan struct{}), release: make(chan struct{})}
+	path := filepath.Join(t.TempDir(), "goplum.conf")
+	if err := os.WriteFile(path, []byte(`
+alert test.nop "nop" {}
+check test.block "blocking" {}
+`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	plum := NewPlum()
+	plum.RegisterPlugin("test", func() (Plugin, error) { return plugin, nil })
+	if err := plum.ReadConfig(path); err != nil {
+		t.Fatal(err)
+	}
+
+	go plum.processScheduledChecks()
+	due, _ := plum.dueChecks(time.Now())
+	if len(due) != 1 {
+		t.Fatalf("Expected one check to be due, got %d", len(due))
+	}
+	plum.scheduled <- due[0]
+	<-plugin.started
+
+	reloaded := make(chan error)
+	go func() {
+		reloaded <- plum.ReloadConfig()
+	}()
+
+	select {
+	case <-reloaded:
+		t.Fatalf("Reload finished while a check was still running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if due, _ := plum.dueChecks(time.Now()); len(due) != 0 {
+		t.Errorf("Expected no checks to be scheduled during a reload, got %d", len(due))
+	}
+
+	close(plugin.release)
+	if err := <-reloaded; err != nil {
+		t.Fatal(err)
+	}
+
+	plum.mu.RLock()
+	defer plum.mu.RUnlock()
+
+	check := plum.Checks["blocking"]
+	if check == due[0] {
+		t.Fatalf("Expected the check to be replaced by the reload")
+	}
+
+	if check.LastResult() == nil || check.LastResult().State != StateGood {
+		t.Errorf("Expected the result of the running check to be carried over, got %v", check.LastResult())
+	}
+
+	if check.Scheduled {
+		t.Errorf("Expected the replacement check not to be scheduled")
+	}
+}
+
+func TestPlum_RunCheckConcurrentlyWithSave(t *testing.T) {
+	plum := NewPlum()
+	plum.Checks["test"] = &ScheduledCheck{Name: "test", Check: goodCheck{}, Config: &CheckSettings{Timeout: time.Second}}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			plum.RunCheck(plum.Checks["test"])
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			plum.mu.RLock()
+			_ = NewTombStone(plum.Checks)
+			plum.mu.RUnlock()
+		}
+	}()
+	wg.Wait()
+
+	if plum.Checks["test"].LastResult() == nil {
+		t.Errorf("Expected the check to have a result")
+	}
+}
+
+func TestPlum_SendAlertsConcurrentlyWithSave(t *testing.T) {
+	plum := NewPlum()
+	plum.Alerts["nop"] = nopAlert{}
+	plum.Checks["test"] = &ScheduledCheck{
+		Name:    "test",
+		Check:   goodCheck{},
+		State:   StateFailing,
+		History: ResultHistory{&Result{State: StateFailing}},
+		Config:  &CheckSettings{Timeout: time.Second},
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			plum.sendAlerts(plum.Checks["test"], StateGood, false, []string{"nop"})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			plum.mu.RLock()
+			_ = NewTombStone(plum.Checks)
+			plum.mu.RUnlock()
+		}
+	}()
+	wg.Wait()
+
+	if plum.Checks["test"].LastAlertTime.IsZero() {
+		t.Errorf("Expected alerts to have been sent")
+	}
+}
+
+func TestPlum_EscalateConcurrentlyWithSave(t *testing.T) {
+	plum := NewPlum()
+	plum.Alerts["nop"] = nopAlert{}
+	plum.Escalations["policy"] = &Escalation{Name: "policy", Steps: []*EscalationStep{{Name: "first", Alerts: []string{"nop"}}}}
+	plum.Checks["test"] = &ScheduledCheck{
+		Name:    "test",
+		Check:   flappingCheck{runs: &atomic.Int32{}},
+		Settled: true,
+		Config: &CheckSettings{
+			Timeout:          time.Second,
+			EscalationPolicy: "policy",
+			GoodThreshold:    1,
+			FailingThreshold: 1,
+		},
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for range 1000 {
+			plum.RunCheck(plum.Checks["test"])
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 1000 {
+			plum.mu.RLock()
+			_ = NewTombStone(plum.Checks)
+			plum.mu.RUnlock()
+		}
+	}()
+	wg.Wait()
+}
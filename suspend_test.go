@@ -0,0 +1,115 @@
+package goplum
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"chameth.com/goplum/api"
+)
+
+func TestPlum_SuspendRecordsDetails(t *testing.T) {
+	plum := NewPlum()
+	plum.Checks["test"] = &ScheduledCheck{Name: "test"}
+
+	check := plum.Suspend("test", time.Hour, "Replacing disks", "alice")
+	if check == nil {
+		t.Fatalf("Expected check to be returned")
+	}
+
+	if !check.Suspended || check.SuspendedReason != "Replacing disks" || check.SuspendedBy != "alice" {
+		t.Errorf("Suspension details not recorded: %+v", check)
+	}
+
+	if until := time.Until(check.SuspendedUntil); until <= 59*time.Minute || until > time.Hour {
+		t.Errorf("Expected check to be suspended for an hour, got %s", until)
+	}
+
+	plum.Unsuspend("test")
+	if check.Suspended || !check.SuspendedUntil.IsZero() || check.SuspendedReason != "" || check.SuspendedBy != "" {
+		t.Errorf("Suspension details not cleared: %+v", check)
+	}
+}
+
+func TestScheduledCheck_SuspensionExpires(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		until     time.Time
+		suspended bool
+	}{
+		{"Indefinite", time.Time{}, true},
+		{"NotExpired", now.Add(time.Second), true},
+		{"Expired", now, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check := &ScheduledCheck{Name: "test", Suspended: true, SuspendedUntil: test.until, SuspendedReason: "reason"}
+
+			if actual := check.checkSuspension(now); actual != test.suspended {
+				t.Errorf("Expected suspended to be %t, got %t", test.suspended, actual)
+			}
+
+			if !test.suspended && check.SuspendedReason != "" {
+				t.Errorf("Expected suspension reason to be cleared")
+			}
+		})
+	}
+}
+
+func TestCheckTombStone_RestoresSuspension(t *testing.T) {
+	original := &ScheduledCheck{
+		Name:            "test",
+		Suspended:       true,
+		SuspendedUntil:  time.Now().Add(time.Hour).Truncate(time.Second),
+		SuspendedReason: "Replacing disks",
+		SuspendedBy:     "alice",
+	}
+
+	restored := &ScheduledCheck{Name: "test"}
+	newCheckTombStone(original).restore(restored)
+
+	if !restored.Suspended || !restored.SuspendedUntil.Equal(original.SuspendedUntil) || restored.SuspendedReason != original.SuspendedReason || restored.SuspendedBy != original.SuspendedBy {
+		t.Errorf("Suspension not restored: %+v", restored)
+	}
+}
+
+func TestPlum_SuspendConcurrently(t *testing.T) {
+	plum := NewPlum()
+	plum.Checks["test"] = &ScheduledCheck{Name: "test", Config: &CheckSettings{Interval: time.Minute}}
+	server := NewGrpcServer(plum)
+
+	var wg sync.WaitGroup
+	run := func(f func(i int)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				f(i)
+			}
+		}()
+	}
+
+	run(func(i int) {
+		// Suspensions expire immediately, so the scheduler will also be unsuspending the check.
+		plum.Suspend("test", time.Nanosecond, fmt.Sprintf("reason %d", i), "alice")
+	})
+	run(func(int) { plum.Unsuspend("test") })
+	run(func(int) { plum.dueChecks(time.Now()) })
+	run(func(int) {
+		if _, err := server.GetChecks(context.Background(), &api.Empty{}); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+	run(func(int) {
+		if _, err := server.ResumeCheck(context.Background(), &api.CheckName{Name: "test"}); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	wg.Wait()
+}
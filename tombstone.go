@@ -14,8 +14,9 @@ var tombStonePath = flag.String("tombstone", "/tmp/goplum.tomb", "Path to save t
 const maxTombStoneAge = 10 * time.Minute
 
 type TombStone struct {
-	Time   time.Time
-	Checks map[string]CheckTombStone
+	Time        time.Time
+	Checks      map[string]CheckTombStone
+	Maintenance []*MaintenanceWindow `json:",omitempty"`
 }
 
 type CheckTombStone struct {
@@ -26,6 +27,20 @@ type CheckTombStone struct {
 	LastAlertTime time.Time `json:"last_alert_time,omitzero"`
 	History       ResultHistory
 	PluginState   json.RawMessage `json:"plugin_state,omitempty"`
+
+	SuspendedUntil  time.Time `json:"suspended_until,omitzero"`
+	SuspendedReason string    `json:"suspended_reason,omitempty"`
+	SuspendedBy     string    `json:"suspended_by,omitempty"`
+
+	FailingSince    time.Time `json:"failing_since,omitzero"`
+	EscalationLevel int       `json:"escalation_level,omitempty"`
+
+	FailureSuppressed bool `json:"failure_suppressed,omitempty"`
+
+	Acknowledged        bool      `json:"acknowledged,omitempty"`
+	AcknowledgedAt      time.Time `json:"acknowledged_at,omitzero"`
+	AcknowledgedBy      string    `json:"acknowledged_by,omitempty"`
+	AcknowledgedMessage string    `json:"acknowledged_message,omitempty"`
 }
 
 func NewTombStone(checks map[string]*ScheduledCheck) *TombStone {
@@ -35,29 +50,47 @@ func NewTombStone(checks map[string]*ScheduledCheck) *TombStone {
 	}
 
 	for i := range checks {
-		var state []byte
-		check := checks[i]
-
-		if stateful, ok := check.Check.(Stateful); ok {
-			var err error
-			state, err = json.Marshal(stateful.Save())
-			if err != nil {
-				log.Printf("Unable to save state of check %s: %v", check.Name, err)
-			}
-		}
+		ts.Checks[checks[i].Name] = newCheckTombStone(checks[i])
+	}
+
+	return ts
+}
+
+// newCheckTombStone captures the persistable state of a single check.
+func newCheckTombStone(check *ScheduledCheck) CheckTombStone {
+	var state []byte
 
-		ts.Checks[check.Name] = CheckTombStone{
-			LastRun:       check.LastRun,
-			Settled:       check.Settled,
-			State:         check.State,
-			Suspended:     check.Suspended,
-			LastAlertTime: check.LastAlertTime,
-			History:       check.History,
-			PluginState:   state,
+	if stateful, ok := check.Check.(Stateful); ok {
+		var err error
+		state, err = json.Marshal(stateful.Save())
+		if err != nil {
+			log.Printf("Unable to save state of check %s: %v", check.Name, err)
 		}
 	}
 
-	return ts
+	return CheckTombStone{
+		LastRun:       check.LastRun,
+		Settled:       check.Settled,
+		State:         check.State,
+		Suspended:     check.Suspended,
+		LastAlertTime: check.LastAlertTime,
+		History:       check.History,
+		PluginState:   state,
+
+		SuspendedUntil:  check.SuspendedUntil,
+		SuspendedReason: check.SuspendedReason,
+		SuspendedBy:     check.SuspendedBy,
+
+		FailingSince:    check.FailingSince,
+		EscalationLevel: check.EscalationLevel,
+
+		FailureSuppressed: check.FailureSuppressed,
+
+		Acknowledged:        check.Acknowledged,
+		AcknowledgedAt:      check.AcknowledgedAt,
+		AcknowledgedBy:      check.AcknowledgedBy,
+		AcknowledgedMessage: check.AcknowledgedMessage,
+	}
 }
 
 func LoadTombStone() (*TombStone, error) {
@@ -89,24 +122,38 @@ func (ts *TombStone) Restore(checks map[string]*ScheduledCheck) error {
 	}
 
 	for i := range checks {
-		check := checks[i]
-		if saved, ok := ts.Checks[check.Name]; ok {
-			check.LastRun = saved.LastRun
-			check.Settled = saved.Settled
-			check.State = saved.State
-			check.Suspended = saved.Suspended
-			check.LastAlertTime = saved.LastAlertTime
-			check.History = saved.History
-
-			if stateful, ok := check.Check.(Stateful); ok && saved.PluginState != nil {
-				stateful.Restore(func(i any) {
-					if err := json.Unmarshal(saved.PluginState, i); err != nil {
-						log.Printf("Unable to restore state of check %s: %v", check.Name, err)
-					}
-				})
-			}
+		if saved, ok := ts.Checks[checks[i].Name]; ok {
+			saved.restore(checks[i])
 		}
 	}
 
 	return nil
 }
+
+// restore applies the saved state to the given check.
+func (s CheckTombStone) restore(check *ScheduledCheck) {
+	check.LastRun = s.LastRun
+	check.Settled = s.Settled
+	check.State = s.State
+	check.Suspended = s.Suspended
+	check.LastAlertTime = s.LastAlertTime
+	check.History = s.History
+	check.SuspendedUntil = s.SuspendedUntil
+	check.SuspendedReason = s.SuspendedReason
+	check.SuspendedBy = s.SuspendedBy
+	check.FailingSince = s.FailingSince
+	check.EscalationLevel = s.EscalationLevel
+	check.FailureSuppressed = s.FailureSuppressed
+	check.Acknowledged = s.Acknowledged
+	check.AcknowledgedAt = s.AcknowledgedAt
+	check.AcknowledgedBy = s.AcknowledgedBy
+	check.AcknowledgedMessage = s.AcknowledgedMessage
+
+	if stateful, ok := check.Check.(Stateful); ok && s.PluginState != nil {
+		stateful.Restore(func(i any) {
+			if err := json.Unmarshal(s.PluginState, i); err != nil {
+				log.Printf("Unable to restore state of check %s: %v", check.Name, err)
+			}
+		})
+	}
+}